@@ -0,0 +1,307 @@
+package g
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"go-extend/l"
+)
+
+// Graph is a directed or undirected graph stored as adjacency lists.
+// Nodes are identified by comparable values and every edge carries a value of type E, for example a weight
+// or a label. There is at most one edge between two nodes in each direction. Nodes and edges are iterated in
+// insertion order, so all algorithms of the package produce deterministic results.
+type Graph[N comparable, E any] struct {
+	directed bool
+	nodes    map[N]*node[N, E]
+	order    []N
+	edges    int
+}
+
+// Edge is an edge of a Graph. In an undirected graph, From and To are interchangeable.
+type Edge[N comparable, E any] struct {
+	From  N
+	To    N
+	Value E
+}
+
+type node[N comparable, E any] struct {
+	out adjacency[N, E]
+	in  adjacency[N, E]
+}
+
+// adjacency is an insertion-ordered set of neighbours with the values of the connecting edges.
+type adjacency[N comparable, E any] struct {
+	order  []N
+	values map[N]E
+}
+
+// NewDirectedGraph creates an empty directed graph.
+//
+// Example usage:
+//
+//	g := NewDirectedGraph[string, int]()
+//	g.AddEdge("build", "test", 1)
+//	g.AddEdge("test", "deploy", 1)
+//	order, _ := g.TopologicalSort() // build, test, deploy
+func NewDirectedGraph[N comparable, E any]() *Graph[N, E] {
+	return &Graph[N, E]{directed: true, nodes: map[N]*node[N, E]{}}
+}
+
+// NewUndirectedGraph creates an empty undirected graph.
+func NewUndirectedGraph[N comparable, E any]() *Graph[N, E] {
+	return &Graph[N, E]{nodes: map[N]*node[N, E]{}}
+}
+
+// Directed returns true if the graph is directed.
+func (g *Graph[N, E]) Directed() bool {
+	return g.directed
+}
+
+// AddNode adds the nodes that are not yet in the graph. It returns the number of added nodes.
+func (g *Graph[N, E]) AddNode(nodes ...N) int {
+	added := 0
+	for _, n := range nodes {
+		if _, ok := g.nodes[n]; !ok {
+			g.nodes[n] = &node[N, E]{}
+			g.order = append(g.order, n)
+			added++
+		}
+	}
+	return added
+}
+
+// AddEdge adds an edge between the nodes, adding the nodes first if they are not in the graph.
+// If the edge already exists, its value is replaced. It returns true if the edge is new.
+func (g *Graph[N, E]) AddEdge(from, to N, value E) bool {
+	g.AddNode(from, to)
+	added := g.nodes[from].out.put(to, value)
+	if g.directed {
+		g.nodes[to].in.put(from, value)
+	} else if from != to {
+		g.nodes[to].out.put(from, value)
+	}
+	if added {
+		g.edges++
+	}
+	return added
+}
+
+// RemoveEdge removes the edge between the nodes. It returns true if the edge was in the graph.
+func (g *Graph[N, E]) RemoveEdge(from, to N) bool {
+	source, ok := g.nodes[from]
+	if !ok || !source.out.remove(to) {
+		return false
+	}
+	if g.directed {
+		g.nodes[to].in.remove(from)
+	} else {
+		g.nodes[to].out.remove(from)
+	}
+	g.edges--
+	return true
+}
+
+// RemoveNode removes the node and all its edges. It returns true if the node was in the graph.
+func (g *Graph[N, E]) RemoveNode(n N) bool {
+	current, ok := g.nodes[n]
+	if !ok {
+		return false
+	}
+
+	for _, to := range append([]N(nil), current.out.order...) {
+		g.RemoveEdge(n, to)
+	}
+	for _, from := range append([]N(nil), current.in.order...) {
+		g.RemoveEdge(from, n)
+	}
+	delete(g.nodes, n)
+	for i, other := range g.order {
+		if other == n {
+			g.order = append(g.order[:i], g.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// HasNode returns true if the node is in the graph.
+func (g *Graph[N, E]) HasNode(n N) bool {
+	_, ok := g.nodes[n]
+	return ok
+}
+
+// HasEdge returns true if there is an edge from one node to the other.
+func (g *Graph[N, E]) HasEdge(from, to N) bool {
+	return g.Edge(from, to) != nil
+}
+
+// Edge returns a pointer to the value of the edge between the nodes. If there is no such edge, it returns nil.
+func (g *Graph[N, E]) Edge(from, to N) *E {
+	source, ok := g.nodes[from]
+	if !ok {
+		return nil
+	}
+	value, ok := source.out.values[to]
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+// NodeCount returns the number of nodes in the graph.
+func (g *Graph[N, E]) NodeCount() int {
+	return len(g.order)
+}
+
+// EdgeCount returns the number of edges in the graph. An undirected edge is counted once.
+func (g *Graph[N, E]) EdgeCount() int {
+	return g.edges
+}
+
+// Nodes returns a List with all nodes in insertion order.
+func (g *Graph[N, E]) Nodes() *l.List[N] {
+	nodes := l.NewList(slices.Clone(g.order)...)
+	return &nodes
+}
+
+// Edges returns a List with all edges, grouped by their source node. In an undirected graph, every edge is
+// returned once.
+func (g *Graph[N, E]) Edges() *l.List[Edge[N, E]] {
+	edges := l.NewList[Edge[N, E]]()
+	seen := map[N]bool{}
+	for _, from := range g.order {
+		g.nodes[from].out.forEach(func(to N, value E) {
+			if g.directed || !seen[to] {
+				edges.Add(Edge[N, E]{From: from, To: to, Value: value})
+			}
+		})
+		seen[from] = true
+	}
+	return &edges
+}
+
+// Successors returns a List with the nodes that the node has an edge to. In an undirected graph, these are
+// all neighbours of the node.
+func (g *Graph[N, E]) Successors(n N) *l.List[N] {
+	successors := l.NewList[N]()
+	if current, ok := g.nodes[n]; ok {
+		successors.Add(current.out.order...)
+	}
+	return &successors
+}
+
+// Predecessors returns a List with the nodes that have an edge to the node. In an undirected graph, these are
+// all neighbours of the node.
+func (g *Graph[N, E]) Predecessors(n N) *l.List[N] {
+	current, ok := g.nodes[n]
+	if !ok {
+		list := l.NewList[N]()
+		return &list
+	}
+	if !g.directed {
+		return g.Successors(n)
+	}
+	predecessors := l.NewList(slices.Clone(current.in.order)...)
+	return &predecessors
+}
+
+// OutDegree returns the number of edges that leave the node.
+func (g *Graph[N, E]) OutDegree(n N) int {
+	if current, ok := g.nodes[n]; ok {
+		return len(current.out.order)
+	}
+	return 0
+}
+
+// InDegree returns the number of edges that enter the node. In an undirected graph, it equals OutDegree.
+func (g *Graph[N, E]) InDegree(n N) int {
+	if !g.directed {
+		return g.OutDegree(n)
+	}
+	if current, ok := g.nodes[n]; ok {
+		return len(current.in.order)
+	}
+	return 0
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language. Nodes are labelled with their fmt representation.
+// If edgeLabel is not nil, it is called for every edge and its result is used as the edge label.
+//
+// Example usage:
+//
+//	g.WriteDOT(os.Stdout, func(e Edge[string, int]) string { return strconv.Itoa(e.Value) })
+func (g *Graph[N, E]) WriteDOT(w io.Writer, edgeLabel func(Edge[N, E]) string) error {
+	kind, connector := "graph", "--"
+	if g.directed {
+		kind, connector = "digraph", "->"
+	}
+
+	if _, err := fmt.Fprintf(w, "%s {\n", kind); err != nil {
+		return err
+	}
+	for _, n := range g.order {
+		if _, err := fmt.Fprintf(w, "\t%s;\n", dotID(n)); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	edges := g.Edges()
+	edges.ForEach(func(_ int, e Edge[N, E]) {
+		if err != nil {
+			return
+		}
+		line := fmt.Sprintf("\t%s %s %s", dotID(e.From), connector, dotID(e.To))
+		if edgeLabel != nil {
+			line += fmt.Sprintf(" [label=%s]", strconv.Quote(edgeLabel(e)))
+		}
+		_, err = fmt.Fprintf(w, "%s;\n", line)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, "}")
+	return err
+}
+
+func dotID(n any) string {
+	return strconv.Quote(fmt.Sprint(n))
+}
+
+// put stores the value of the edge to the neighbour. It returns true if the neighbour is new.
+func (a *adjacency[N, E]) put(neighbour N, value E) bool {
+	if a.values == nil {
+		a.values = map[N]E{}
+	}
+	_, exists := a.values[neighbour]
+	a.values[neighbour] = value
+	if !exists {
+		a.order = append(a.order, neighbour)
+	}
+	return !exists
+}
+
+// remove removes the neighbour. It returns true if it was in the set.
+func (a *adjacency[N, E]) remove(neighbour N) bool {
+	if _, ok := a.values[neighbour]; !ok {
+		return false
+	}
+	delete(a.values, neighbour)
+	for i, other := range a.order {
+		if other == neighbour {
+			a.order = append(a.order[:i], a.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (a *adjacency[N, E]) forEach(f func(neighbour N, value E)) {
+	for _, neighbour := range a.order {
+		f(neighbour, a.values[neighbour])
+	}
+}
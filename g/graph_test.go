@@ -0,0 +1,161 @@
+package g
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"go-extend/l"
+)
+
+func edgeList[N comparable, E any](g *Graph[N, E]) []Edge[N, E] {
+	return g.Edges().Slice()
+}
+
+func TestGraph_Directed(t *testing.T) {
+	g := NewDirectedGraph[string, int]()
+	g.AddNode("a")
+	if !g.AddEdge("a", "b", 1) || !g.AddEdge("a", "c", 2) || !g.AddEdge("c", "b", 3) {
+		t.Fatalf("AddEdge() of new edge = false, want true")
+	}
+	if g.AddEdge("a", "b", 10) {
+		t.Errorf("AddEdge() of existing edge = true, want false")
+	}
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "node count", got: g.NodeCount(), want: 3},
+		{name: "edge count", got: g.EdgeCount(), want: 3},
+		{name: "nodes", got: g.Nodes().Slice(), want: []string{"a", "b", "c"}},
+		{name: "edge value", got: *g.Edge("a", "b"), want: 10},
+		{name: "reverse edge", got: g.HasEdge("b", "a"), want: false},
+		{name: "successors", got: g.Successors("a").Slice(), want: []string{"b", "c"}},
+		{name: "predecessors", got: g.Predecessors("b").Slice(), want: []string{"a", "c"}},
+		{name: "out degree", got: g.OutDegree("a"), want: 2},
+		{name: "in degree", got: g.InDegree("b"), want: 2},
+		{name: "edges", got: edgeList(g), want: []Edge[string, int]{{"a", "b", 10}, {"a", "c", 2}, {"c", "b", 3}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.got, tc.want) {
+				t.Errorf("got %v, want %v", tc.got, tc.want)
+			}
+		})
+	}
+
+	if !g.RemoveNode("c") || g.RemoveNode("c") {
+		t.Fatalf("RemoveNode() returned a wrong result")
+	}
+	if g.EdgeCount() != 1 || g.InDegree("b") != 1 || g.HasNode("c") {
+		t.Errorf("RemoveNode() left edges behind: %v", edgeList(g))
+	}
+	if !g.RemoveEdge("a", "b") || g.RemoveEdge("a", "b") || g.EdgeCount() != 0 {
+		t.Errorf("RemoveEdge() returned a wrong result")
+	}
+}
+
+func TestGraph_Undirected(t *testing.T) {
+	g := NewUndirectedGraph[int, string]()
+	g.AddEdge(1, 2, "x")
+	g.AddEdge(2, 3, "y")
+	g.AddEdge(3, 3, "loop")
+
+	if g.Directed() || g.EdgeCount() != 3 {
+		t.Errorf("EdgeCount() = %d, want 3", g.EdgeCount())
+	}
+	if !g.HasEdge(2, 1) || *g.Edge(3, 2) != "y" {
+		t.Errorf("undirected edge is not symmetric")
+	}
+	if got := g.Predecessors(2).Slice(); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Predecessors(2) = %v, want [1 3]", got)
+	}
+	want := []Edge[int, string]{{1, 2, "x"}, {2, 3, "y"}, {3, 3, "loop"}}
+	if got := edgeList(g); !reflect.DeepEqual(got, want) {
+		t.Errorf("Edges() = %v, want %v", got, want)
+	}
+
+	g.RemoveNode(2)
+	if g.EdgeCount() != 1 || g.HasEdge(1, 2) {
+		t.Errorf("RemoveNode() left edges behind: %v", edgeList(g))
+	}
+}
+
+func TestGraph_WriteDOT(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph *Graph[string, int]
+		label func(Edge[string, int]) string
+		want  string
+	}{
+		{
+			name:  "directed with labels",
+			graph: NewDirectedGraph[string, int](),
+			label: func(e Edge[string, int]) string { return strconv.Itoa(e.Value) },
+			want:  "digraph {\n\t\"a\";\n\t\"b \\\"quoted\\\"\";\n\t\"a\" -> \"b \\\"quoted\\\"\" [label=\"7\"];\n}\n",
+		},
+		{
+			name:  "undirected",
+			graph: NewUndirectedGraph[string, int](),
+			want:  "graph {\n\t\"a\";\n\t\"b \\\"quoted\\\"\";\n\t\"a\" -- \"b \\\"quoted\\\"\";\n}\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.graph.AddEdge("a", `b "quoted"`, 7)
+			var buffer bytes.Buffer
+			if err := tc.graph.WriteDOT(&buffer, tc.label); err != nil {
+				t.Fatalf("WriteDOT() error = %v", err)
+			}
+			if buffer.String() != tc.want {
+				t.Errorf("WriteDOT() = %q, want %q", buffer.String(), tc.want)
+			}
+		})
+	}
+}
+
+func TestGraph_ListsAreCopies(t *testing.T) {
+	tests := []struct {
+		name  string
+		list  func(g *Graph[string, int]) *l.List[string]
+		check func(g *Graph[string, int]) []string
+	}{
+		{name: "nodes", list: (*Graph[string, int]).Nodes, check: func(g *Graph[string, int]) []string { return g.Nodes().Slice() }},
+		{
+			name:  "successors",
+			list:  func(g *Graph[string, int]) *l.List[string] { return g.Successors("a") },
+			check: func(g *Graph[string, int]) []string { return g.Successors("a").Slice() },
+		},
+		{
+			name:  "predecessors",
+			list:  func(g *Graph[string, int]) *l.List[string] { return g.Predecessors("c") },
+			check: func(g *Graph[string, int]) []string { return g.Predecessors("c").Slice() },
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewDirectedGraph[string, int]()
+			g.AddEdge("a", "b", 1)
+			g.AddEdge("a", "c", 1)
+			g.AddEdge("b", "c", 1)
+			want := append([]string{}, tc.check(g)...)
+
+			list := tc.list(g)
+			*list.Get(0) = "zzz"
+			list.Remove(0)
+			list.Add("yyy")
+
+			if got := tc.check(g); !reflect.DeepEqual(got, want) {
+				t.Errorf("graph changed through the returned List: got %v, want %v", got, want)
+			}
+			if !g.HasEdge("a", "b") || !g.HasEdge("b", "c") || g.HasNode("zzz") {
+				t.Errorf("graph edges changed through the returned List")
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package g
+
+import (
+	"errors"
+
+	"go-extend/l"
+)
+
+var (
+	// ErrNoPath is returned when the target node cannot be reached from the source node.
+	ErrNoPath = errors.New("g: no path between the nodes")
+	// ErrNegativeWeight is returned when a weight function returns a negative weight.
+	ErrNegativeWeight = errors.New("g: negative edge weight")
+)
+
+// Path is a path through a graph found by a shortest path search.
+type Path[N comparable] struct {
+	// Nodes lists the nodes of the path from the source to the target, both included.
+	Nodes []N
+	// Cost is the sum of the weights of the edges on the path.
+	Cost float64
+}
+
+// Weight returns the weight of an edge for shortest path searches. Weights must not be negative.
+type Weight[N comparable, E any] func(e Edge[N, E]) float64
+
+// ShortestPath finds the cheapest path between the nodes with Dijkstra's algorithm.
+// It returns ErrNoPath if there is no path and ErrNegativeWeight if the search meets a negative weight.
+//
+// Example usage:
+//
+//	path, err := g.ShortestPath("a", "d", func(e Edge[string, float64]) float64 { return e.Value })
+func (g *Graph[N, E]) ShortestPath(from, to N, weight Weight[N, E]) (Path[N], error) {
+	return g.AStar(from, to, weight, func(N) float64 { return 0 })
+}
+
+// AStar finds the cheapest path between the nodes with the A* algorithm. The heuristic estimates the cost from
+// a node to the target. It must never overestimate the cost, otherwise the returned path may not be the cheapest.
+// The heuristic does not have to be consistent: a node that was already expanded is expanded again when a cheaper
+// path to it is found.
+// It returns ErrNoPath if there is no path and ErrNegativeWeight if the search meets a negative weight.
+func (g *Graph[N, E]) AStar(from, to N, weight Weight[N, E], heuristic func(n N) float64) (Path[N], error) {
+	if !g.HasNode(from) || !g.HasNode(to) {
+		return Path[N]{}, ErrNoPath
+	}
+
+	type entry struct {
+		node     N
+		cost     float64
+		estimate float64
+	}
+	costs := map[N]float64{from: 0}
+	previous := map[N]N{}
+	queue := l.NewPriorityQueue(func(a, b entry) bool { return a.estimate < b.estimate }, entry{node: from, estimate: heuristic(from)})
+
+	for !queue.IsEmpty() {
+		current := *queue.Pop()
+		if current.cost > costs[current.node] {
+			// A cheaper path to the node was found after this entry was pushed.
+			continue
+		}
+		if current.node == to {
+			return Path[N]{Nodes: buildPath(previous, from, to), Cost: current.cost}, nil
+		}
+
+		var err error
+		g.nodes[current.node].out.forEach(func(next N, value E) {
+			w := weight(Edge[N, E]{From: current.node, To: next, Value: value})
+			if w < 0 {
+				err = ErrNegativeWeight
+			}
+			cost := current.cost + w
+			if known, ok := costs[next]; !ok || cost < known {
+				costs[next] = cost
+				previous[next] = current.node
+				queue.Push(entry{node: next, cost: cost, estimate: cost + heuristic(next)})
+			}
+		})
+		if err != nil {
+			return Path[N]{}, err
+		}
+	}
+	return Path[N]{}, ErrNoPath
+}
+
+// Distances returns the cost of the cheapest path from the node to every reachable node, computed with
+// Dijkstra's algorithm. It returns ErrNegativeWeight if the search meets a negative weight.
+func (g *Graph[N, E]) Distances(from N, weight Weight[N, E]) (map[N]float64, error) {
+	if !g.HasNode(from) {
+		return map[N]float64{}, nil
+	}
+
+	type entry struct {
+		node N
+		cost float64
+	}
+	costs := map[N]float64{from: 0}
+	done := map[N]bool{}
+	queue := l.NewPriorityQueue(func(a, b entry) bool { return a.cost < b.cost }, entry{node: from})
+
+	for !queue.IsEmpty() {
+		current := *queue.Pop()
+		if done[current.node] {
+			continue
+		}
+		done[current.node] = true
+
+		var err error
+		g.nodes[current.node].out.forEach(func(next N, value E) {
+			w := weight(Edge[N, E]{From: current.node, To: next, Value: value})
+			if w < 0 {
+				err = ErrNegativeWeight
+			}
+			if cost := current.cost + w; !done[next] {
+				if known, ok := costs[next]; !ok || cost < known {
+					costs[next] = cost
+					queue.Push(entry{node: next, cost: cost})
+				}
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return costs, nil
+}
+
+func buildPath[N comparable](previous map[N]N, from, to N) []N {
+	path := []N{to}
+	for current := to; current != from; {
+		current = previous[current]
+		path = append(path, current)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
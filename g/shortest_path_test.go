@@ -0,0 +1,129 @@
+package g
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func weightOf(e Edge[string, float64]) float64 {
+	return e.Value
+}
+
+func TestGraph_ShortestPath(t *testing.T) {
+	g := NewDirectedGraph[string, float64]()
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("c", "b", 2)
+	g.AddEdge("b", "d", 1)
+	g.AddEdge("c", "d", 5)
+	g.AddNode("island")
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     []string
+		cost     float64
+		wantErr  error
+	}{
+		{name: "cheaper detour", from: "a", to: "d", want: []string{"a", "c", "b", "d"}, cost: 4},
+		{name: "same node", from: "b", to: "b", want: []string{"b"}, cost: 0},
+		{name: "against direction", from: "d", to: "a", wantErr: ErrNoPath},
+		{name: "unreachable", from: "a", to: "island", wantErr: ErrNoPath},
+		{name: "missing node", from: "a", to: "x", wantErr: ErrNoPath},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := g.ShortestPath(tc.from, tc.to, weightOf)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("ShortestPath() error = %v, want %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(path.Nodes, tc.want) || path.Cost != tc.cost {
+				t.Errorf("ShortestPath() = %v (%v), want %v (%v)", path.Nodes, path.Cost, tc.want, tc.cost)
+			}
+		})
+	}
+
+	distances, err := g.Distances("a", weightOf)
+	if err != nil {
+		t.Fatalf("Distances() error = %v", err)
+	}
+	if want := map[string]float64{"a": 0, "b": 3, "c": 1, "d": 4}; !reflect.DeepEqual(distances, want) {
+		t.Errorf("Distances() = %v, want %v", distances, want)
+	}
+
+	g.AddEdge("d", "a", -1)
+	if _, err := g.Distances("a", weightOf); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Distances() error = %v, want %v", err, ErrNegativeWeight)
+	}
+	if _, err := g.ShortestPath("d", "a", weightOf); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("ShortestPath() error = %v, want %v", err, ErrNegativeWeight)
+	}
+}
+
+type point struct{ x, y int }
+
+func weightOfPoint(e Edge[point, float64]) float64 {
+	return e.Value
+}
+
+func TestGraph_AStar(t *testing.T) {
+	g := NewUndirectedGraph[point, float64]()
+
+	// A 10x10 grid with a wall at x == 5 that is open only at y == 9.
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if x < 9 && (x != 4 || y == 9) {
+				g.AddEdge(point{x, y}, point{x + 1, y}, 1)
+			}
+			if y < 9 && x != 5 {
+				g.AddEdge(point{x, y}, point{x, y + 1}, 1)
+			}
+		}
+	}
+
+	goal := point{9, 0}
+	heuristic := func(p point) float64 {
+		return math.Abs(float64(goal.x-p.x)) + math.Abs(float64(goal.y-p.y))
+	}
+	path, err := g.AStar(point{0, 0}, goal, weightOfPoint, heuristic)
+	if err != nil {
+		t.Fatalf("AStar() error = %v", err)
+	}
+
+	dijkstra, _ := g.ShortestPath(point{0, 0}, goal, weightOfPoint)
+	if path.Cost != dijkstra.Cost || path.Cost != 27 {
+		t.Errorf("AStar() cost = %v, Dijkstra cost = %v, want 27", path.Cost, dijkstra.Cost)
+	}
+	if len(path.Nodes) != 28 || path.Nodes[0] != (point{0, 0}) || path.Nodes[27] != goal {
+		t.Errorf("AStar() path = %v", path.Nodes)
+	}
+}
+
+func TestGraph_AStar_InconsistentHeuristic(t *testing.T) {
+	g := NewDirectedGraph[string, float64]()
+	g.AddEdge("s", "x", 1)
+	g.AddEdge("x", "n", 1)
+	g.AddEdge("s", "n", 3)
+	g.AddEdge("n", "t", 10)
+
+	// The heuristic never overestimates, but it is not consistent: the cheap path to n through x is found only
+	// after n was expanded through the direct edge.
+	heuristic := func(node string) float64 {
+		if node == "x" {
+			return 10
+		}
+		return 0
+	}
+	weight := func(e Edge[string, float64]) float64 { return e.Value }
+
+	path, err := g.AStar("s", "t", weight, heuristic)
+	if err != nil {
+		t.Fatalf("AStar() error = %v", err)
+	}
+	if path.Cost != 12 || !reflect.DeepEqual(path.Nodes, []string{"s", "x", "n", "t"}) {
+		t.Errorf("AStar() = %v with cost %v, want [s x n t] with cost 12", path.Nodes, path.Cost)
+	}
+}
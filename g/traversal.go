@@ -0,0 +1,235 @@
+package g
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-extend/l"
+)
+
+// ErrUndirected is returned by algorithms that are only defined for directed graphs.
+var ErrUndirected = errors.New("g: graph is undirected")
+
+// CycleError is returned by TopologicalSort when the graph contains a cycle.
+type CycleError[N comparable] struct {
+	// Cycle lists the nodes of one cycle in edge order. The edge from the last node leads back to the first one.
+	Cycle []N
+}
+
+func (e *CycleError[N]) Error() string {
+	nodes := make([]string, 0, len(e.Cycle)+1)
+	for _, n := range e.Cycle {
+		nodes = append(nodes, fmt.Sprint(n))
+	}
+	nodes = append(nodes, fmt.Sprint(e.Cycle[0]))
+	return "g: graph has a cycle: " + strings.Join(nodes, " -> ")
+}
+
+// BFS visits the nodes reachable from start in breadth-first order and calls visit with every node and its
+// distance from start in edges. The traversal stops when visit returns false. If start is not in the graph,
+// visit is never called.
+func (g *Graph[N, E]) BFS(start N, visit func(n N, depth int) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+
+	type entry struct {
+		node  N
+		depth int
+	}
+	visited := map[N]bool{start: true}
+	queue := l.NewQueue(entry{node: start})
+	for queue.Length() > 0 {
+		current := queue.Pop()
+		if !visit(current.node, current.depth) {
+			return
+		}
+		for _, next := range g.nodes[current.node].out.order {
+			if !visited[next] {
+				visited[next] = true
+				queue.Push(entry{node: next, depth: current.depth + 1})
+			}
+		}
+	}
+}
+
+// DFS visits the nodes reachable from start in depth-first pre-order and calls visit with every node.
+// Neighbours are explored in insertion order. The traversal stops when visit returns false. If start is not in
+// the graph, visit is never called.
+func (g *Graph[N, E]) DFS(start N, visit func(n N) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+
+	visited := map[N]bool{}
+	stack := l.NewStack(start)
+	for stack.Length() > 0 {
+		current := *stack.Pop()
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		if !visit(current) {
+			return
+		}
+
+		// The neighbours are pushed in reverse, so the first one is explored first.
+		neighbours := g.nodes[current].out.order
+		for i := len(neighbours) - 1; i >= 0; i-- {
+			if !visited[neighbours[i]] {
+				stack.Push(neighbours[i])
+			}
+		}
+	}
+}
+
+// TopologicalSort returns a List with all nodes ordered so that every edge leads from an earlier node to
+// a later one. Nodes without an ordering constraint keep their insertion order.
+// If the graph contains a cycle, it returns a *CycleError with one of the cycles, and for an undirected graph
+// it returns ErrUndirected.
+func (g *Graph[N, E]) TopologicalSort() (*l.List[N], error) {
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+
+	inDegree := make(map[N]int, len(g.order))
+	ready := l.NewQueue[N]()
+	for _, n := range g.order {
+		inDegree[n] = len(g.nodes[n].in.order)
+		if inDegree[n] == 0 {
+			ready.Push(n)
+		}
+	}
+
+	sorted := l.NewList[N]()
+	for ready.Length() > 0 {
+		current := *ready.Pop()
+		sorted.Add(current)
+		for _, next := range g.nodes[current].out.order {
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				ready.Push(next)
+			}
+		}
+	}
+
+	if sorted.Length() < len(g.order) {
+		return nil, &CycleError[N]{Cycle: g.findCycle(inDegree)}
+	}
+	return &sorted, nil
+}
+
+// findCycle returns a cycle among the nodes that were left with a positive in-degree by TopologicalSort.
+// Every such node has a predecessor that is also left, so walking the predecessors must eventually repeat a node.
+func (g *Graph[N, E]) findCycle(inDegree map[N]int) []N {
+	var current N
+	for _, n := range g.order {
+		if inDegree[n] > 0 {
+			current = n
+			break
+		}
+	}
+
+	positions := map[N]int{}
+	path := []N{}
+	for {
+		if position, ok := positions[current]; ok {
+			path = path[position:]
+			break
+		}
+		positions[current] = len(path)
+		path = append(path, current)
+		for _, previous := range g.nodes[current].in.order {
+			if inDegree[previous] > 0 {
+				current = previous
+				break
+			}
+		}
+	}
+
+	// The path follows the edges backwards. It is reversed and rotated to start at the earliest inserted node.
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	cycle := make(map[N]int, len(path))
+	for i, n := range path {
+		cycle[n] = i
+	}
+	first := 0
+	for _, n := range g.order {
+		if i, ok := cycle[n]; ok {
+			first = i
+			break
+		}
+	}
+	return append(path[first:], path[:first]...)
+}
+
+// StronglyConnectedComponents returns the strongly connected components of the graph. Within a component,
+// every node is reachable from every other node. For an undirected graph, these are the connected components.
+// The components are returned in reverse topological order, and the nodes of every component in the order
+// they were discovered.
+func (g *Graph[N, E]) StronglyConnectedComponents() *l.List[l.List[N]] {
+	// Tarjan's algorithm with an explicit call stack, so that long paths do not exhaust the goroutine stack.
+	type frame struct {
+		node N
+		next int
+	}
+	index := map[N]int{}
+	lowLink := map[N]int{}
+	onStack := map[N]bool{}
+	stack := l.NewStack[N]()
+	components := l.NewList[l.List[N]]()
+
+	for _, root := range g.order {
+		if _, ok := index[root]; ok {
+			continue
+		}
+
+		calls := l.NewStack(frame{node: root})
+		index[root], lowLink[root] = len(index), len(index)
+		stack.Push(root)
+		onStack[root] = true
+
+		for calls.Length() > 0 {
+			call := calls.Peek()
+			neighbours := g.nodes[call.node].out.order
+			if call.next < len(neighbours) {
+				next := neighbours[call.next]
+				call.next++
+				if _, ok := index[next]; !ok {
+					index[next], lowLink[next] = len(index), len(index)
+					stack.Push(next)
+					onStack[next] = true
+					calls.Push(frame{node: next})
+				} else if onStack[next] {
+					lowLink[call.node] = min(lowLink[call.node], index[next])
+				}
+				continue
+			}
+
+			calls.Pop()
+			if parent := calls.Peek(); parent != nil {
+				lowLink[parent.node] = min(lowLink[parent.node], lowLink[call.node])
+			}
+			if lowLink[call.node] == index[call.node] {
+				component := []N{}
+				for {
+					member := *stack.Pop()
+					onStack[member] = false
+					component = append(component, member)
+					if member == call.node {
+						break
+					}
+				}
+				// The stack returns the members in reverse discovery order.
+				for i, j := 0, len(component)-1; i < j; i, j = i+1, j-1 {
+					component[i], component[j] = component[j], component[i]
+				}
+				components.Add(l.NewList(component...))
+			}
+		}
+	}
+	return &components
+}
@@ -0,0 +1,174 @@
+package g
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newTestGraph(directed bool, edges ...[2]string) *Graph[string, int] {
+	g := NewUndirectedGraph[string, int]()
+	if directed {
+		g = NewDirectedGraph[string, int]()
+	}
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1], 1)
+	}
+	return g
+}
+
+func TestGraph_BFS_DFS(t *testing.T) {
+	g := newTestGraph(true, [2]string{"a", "b"}, [2]string{"a", "c"}, [2]string{"b", "d"}, [2]string{"c", "d"}, [2]string{"d", "e"})
+
+	tests := []struct {
+		name   string
+		bfs    bool
+		start  string
+		limit  int
+		want   []string
+		depths []int
+	}{
+		{name: "bfs", bfs: true, start: "a", limit: -1, want: []string{"a", "b", "c", "d", "e"}, depths: []int{0, 1, 1, 2, 3}},
+		{name: "bfs stops", bfs: true, start: "a", limit: 2, want: []string{"a", "b"}, depths: []int{0, 1}},
+		{name: "bfs from middle", bfs: true, start: "c", limit: -1, want: []string{"c", "d", "e"}, depths: []int{0, 1, 2}},
+		{name: "dfs", start: "a", limit: -1, want: []string{"a", "b", "d", "e", "c"}},
+		{name: "dfs stops", start: "a", limit: 3, want: []string{"a", "b", "d"}},
+		{name: "missing start", bfs: true, start: "x", limit: -1, want: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			var depths []int
+			if tc.bfs {
+				g.BFS(tc.start, func(n string, depth int) bool {
+					got = append(got, n)
+					depths = append(depths, depth)
+					return len(got) != tc.limit
+				})
+			} else {
+				g.DFS(tc.start, func(n string) bool {
+					got = append(got, n)
+					return len(got) != tc.limit
+				})
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("visited %v, want %v", got, tc.want)
+			}
+			if tc.bfs && !reflect.DeepEqual(depths, tc.depths) {
+				t.Errorf("depths %v, want %v", depths, tc.depths)
+			}
+		})
+	}
+}
+
+func TestGraph_TopologicalSort(t *testing.T) {
+	tests := []struct {
+		name      string
+		graph     *Graph[string, int]
+		want      []string
+		wantCycle []string
+		wantErr   error
+	}{
+		{
+			name:  "dependencies",
+			graph: newTestGraph(true, [2]string{"compile", "link"}, [2]string{"fetch", "compile"}, [2]string{"link", "test"}, [2]string{"fetch", "test"}),
+			want:  []string{"fetch", "compile", "link", "test"},
+		},
+		{
+			name:      "cycle",
+			graph:     newTestGraph(true, [2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "d"}, [2]string{"d", "b"}),
+			wantCycle: []string{"b", "c", "d"},
+		},
+		{
+			name:      "self loop",
+			graph:     newTestGraph(true, [2]string{"a", "a"}),
+			wantCycle: []string{"a"},
+		},
+		{
+			name:    "undirected",
+			graph:   newTestGraph(false, [2]string{"a", "b"}),
+			wantErr: ErrUndirected,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sorted, err := tc.graph.TopologicalSort()
+			var cycleErr *CycleError[string]
+			switch {
+			case tc.wantErr != nil:
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("TopologicalSort() error = %v, want %v", err, tc.wantErr)
+				}
+			case tc.wantCycle != nil:
+				if !errors.As(err, &cycleErr) {
+					t.Fatalf("TopologicalSort() error = %v, want a CycleError", err)
+				}
+				if !reflect.DeepEqual(cycleErr.Cycle, tc.wantCycle) {
+					t.Errorf("Cycle = %v, want %v", cycleErr.Cycle, tc.wantCycle)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("TopologicalSort() error = %v", err)
+				}
+				if got := sorted.Slice(); !reflect.DeepEqual(got, tc.want) {
+					t.Errorf("TopologicalSort() = %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+
+	err := &CycleError[string]{Cycle: []string{"a", "b"}}
+	if err.Error() != "g: graph has a cycle: a -> b -> a" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestGraph_StronglyConnectedComponents(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph *Graph[string, int]
+		want  [][]string
+	}{
+		{
+			name: "directed",
+			graph: newTestGraph(true,
+				[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"},
+				[2]string{"c", "d"}, [2]string{"d", "e"}, [2]string{"e", "d"}, [2]string{"f", "e"}),
+			want: [][]string{{"d", "e"}, {"a", "b", "c"}, {"f"}},
+		},
+		{
+			name:  "undirected",
+			graph: newTestGraph(false, [2]string{"a", "b"}, [2]string{"c", "d"}, [2]string{"b", "e"}),
+			want:  [][]string{{"a", "b", "e"}, {"c", "d"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got [][]string
+			components := tc.graph.StronglyConnectedComponents()
+			for _, component := range components.Slice() {
+				got = append(got, component.Slice())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("StronglyConnectedComponents() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGraph_LongChain(t *testing.T) {
+	g := NewDirectedGraph[int, struct{}]()
+	const n = 100000
+	for i := 1; i < n; i++ {
+		g.AddEdge(i-1, i, struct{}{})
+	}
+	g.AddEdge(n-1, 0, struct{}{})
+
+	components := g.StronglyConnectedComponents()
+	if components.Length() != 1 || components.Get(0).Length() != n {
+		t.Errorf("StronglyConnectedComponents() of a ring returned %d components", components.Length())
+	}
+}
@@ -0,0 +1,207 @@
+package l
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// HistogramBucket is a bucket of a histogram with the number of items in the range [Lo, Hi).
+// The last bucket of a histogram also contains the items equal to its Hi.
+type HistogramBucket struct {
+	Lo    float64
+	Hi    float64
+	Count int
+}
+
+// Sum returns the sum of the items of the list, or 0 if the list is empty. For floating-point items, KahanSum
+// is more accurate.
+//
+// Example usage:
+//
+//	list := NewList(1, 2, 3, 4)
+//	Sum(&list) // 10
+func Sum[T Number](list *List[T]) T {
+	var sum T
+	for _, item := range list.items {
+		sum += item
+	}
+	return sum
+}
+
+// KahanSum returns the sum of the items of the list with compensated summation, which keeps the rounding error
+// independent of the number of items. It uses the Kahan-Babuska variant by Neumaier, which also handles items that
+// are larger than the running sum.
+//
+// Example usage:
+//
+//	list := NewList(1.0, 1e100, 1.0, -1e100)
+//	Sum(&list)      // 0
+//	KahanSum(&list) // 2
+func KahanSum[T Float](list *List[T]) T {
+	return T(kahanSum(list, func(item T) float64 { return float64(item) }))
+}
+
+// Product returns the product of the items of the list, or 1 if the list is empty.
+func Product[T Number](list *List[T]) T {
+	product := T(1)
+	for _, item := range list.items {
+		product *= item
+	}
+	return product
+}
+
+// Min returns a pointer to a copy of the smallest item of the list. If the list is empty, it returns nil.
+func Min[T cmp.Ordered](list *List[T]) *T {
+	return MinBy(list, func(item T) T { return item })
+}
+
+// Max returns a pointer to a copy of the largest item of the list. If the list is empty, it returns nil.
+func Max[T cmp.Ordered](list *List[T]) *T {
+	return MaxBy(list, func(item T) T { return item })
+}
+
+// MinBy returns a pointer to a copy of the first item of the list with the smallest key. If the list is empty,
+// it returns nil.
+//
+// Example usage:
+//
+//	list := NewList("pear", "fig", "apple")
+//	MinBy(&list, func(s string) int { return len(s) }) // "fig"
+func MinBy[T any, K cmp.Ordered](list *List[T], key func(T) K) *T {
+	return extremeBy(list, key, -1)
+}
+
+// MaxBy returns a pointer to a copy of the first item of the list with the largest key. If the list is empty,
+// it returns nil.
+func MaxBy[T any, K cmp.Ordered](list *List[T], key func(T) K) *T {
+	return extremeBy(list, key, 1)
+}
+
+// Mean returns the arithmetic mean of the items of the list, or NaN if the list is empty.
+func Mean[T Number](list *List[T]) float64 {
+	if len(list.items) == 0 {
+		return math.NaN()
+	}
+	return kahanSum(list, func(item T) float64 { return float64(item) }) / float64(len(list.items))
+}
+
+// Median returns the middle item of the sorted items of the list, or the mean of the two middle items if the
+// length is even. If the list is empty, it returns NaN.
+func Median[T Number](list *List[T]) float64 {
+	return Percentile(list, 50)
+}
+
+// Percentile returns the p-th percentile of the items of the list, for p in [0, 100]. It interpolates linearly
+// between the two closest items, so the 0th percentile is the smallest item, the 50th the median and the 100th
+// the largest item. If the list is empty, it returns NaN. It panics if p is out of range.
+//
+// Example usage:
+//
+//	list := NewList(15, 20, 35, 40, 50)
+//	Percentile(&list, 40) // 29
+func Percentile[T Number](list *List[T], p float64) float64 {
+	if !(p >= 0 && p <= 100) {
+		panic("l: percentile out of range")
+	}
+	if len(list.items) == 0 {
+		return math.NaN()
+	}
+
+	sorted := slices.Clone(list.items)
+	slices.Sort(sorted)
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(rank)
+	if lower == len(sorted)-1 {
+		return float64(sorted[lower])
+	}
+	fraction := rank - float64(lower)
+	return float64(sorted[lower]) + fraction*(float64(sorted[lower+1])-float64(sorted[lower]))
+}
+
+// Variance returns the population variance of the items of the list, or NaN if the list is empty.
+// It uses Welford's algorithm, which avoids the cancellation of subtracting two large sums.
+func Variance[T Number](list *List[T]) float64 {
+	if len(list.items) == 0 {
+		return math.NaN()
+	}
+
+	mean, squares := 0.0, 0.0
+	for i, item := range list.items {
+		value := float64(item)
+		delta := value - mean
+		mean += delta / float64(i+1)
+		squares += delta * (value - mean)
+	}
+	return squares / float64(len(list.items))
+}
+
+// StdDev returns the population standard deviation of the items of the list, or NaN if the list is empty.
+func StdDev[T Number](list *List[T]) float64 {
+	return math.Sqrt(Variance(list))
+}
+
+// Histogram divides the range between the smallest and the largest item of the list into the given number of
+// buckets of equal width and counts the items in every bucket. If the list is empty, it returns an empty List.
+// If all items are equal, they are counted in the first bucket. It panics if buckets is less than 1.
+//
+// Example usage:
+//
+//	list := NewList(1, 2, 2, 3, 9)
+//	Histogram(&list, 2) // [1, 5): 4, [5, 9]: 1
+func Histogram[T Number](list *List[T], buckets int) *List[HistogramBucket] {
+	if buckets < 1 {
+		panic("l: histogram needs at least one bucket")
+	}
+	histogram := NewList[HistogramBucket]()
+	if len(list.items) == 0 {
+		return &histogram
+	}
+
+	lo, hi := float64(*Min(list)), float64(*Max(list))
+	width := (hi - lo) / float64(buckets)
+	for i := 0; i < buckets; i++ {
+		histogram.Add(HistogramBucket{Lo: lo + float64(i)*width, Hi: lo + float64(i+1)*width})
+	}
+	histogram.items[buckets-1].Hi = hi
+
+	for _, item := range list.items {
+		index := 0
+		if width > 0 {
+			index = min(int((float64(item)-lo)/width), buckets-1)
+		}
+		histogram.items[index].Count++
+	}
+	return &histogram
+}
+
+// kahanSum returns the compensated sum of the values of the items.
+func kahanSum[T any](list *List[T], value func(T) float64) float64 {
+	sum, compensation := 0.0, 0.0
+	for _, item := range list.items {
+		v := value(item)
+		t := sum + v
+		if math.Abs(sum) >= math.Abs(v) {
+			compensation += (sum - t) + v
+		} else {
+			compensation += (v - t) + sum
+		}
+		sum = t
+	}
+	return sum + compensation
+}
+
+// extremeBy returns the first item whose key compares to the keys of all other items as sign or 0.
+func extremeBy[T any, K cmp.Ordered](list *List[T], key func(T) K, sign int) *T {
+	if len(list.items) == 0 {
+		return nil
+	}
+
+	best, bestKey := list.items[0], key(list.items[0])
+	for _, item := range list.items[1:] {
+		if k := key(item); cmp.Compare(k, bestKey) == sign {
+			best, bestKey = item, k
+		}
+	}
+	return &best
+}
@@ -0,0 +1,174 @@
+package l
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAggregate_Integers(t *testing.T) {
+	list := NewList(4, 1, 3, 2, 5)
+	empty := NewList[int]()
+
+	if got := Sum(&list); got != 15 {
+		t.Errorf("Sum() = %d, want 15", got)
+	}
+	if got := Sum(&empty); got != 0 {
+		t.Errorf("Sum() of empty list = %d, want 0", got)
+	}
+	if got := Product(&list); got != 120 {
+		t.Errorf("Product() = %d, want 120", got)
+	}
+	if got := Product(&empty); got != 1 {
+		t.Errorf("Product() of empty list = %d, want 1", got)
+	}
+	if got := Min(&list); got == nil || *got != 1 {
+		t.Errorf("Min() = %v, want 1", got)
+	}
+	if got := Max(&list); got == nil || *got != 5 {
+		t.Errorf("Max() = %v, want 5", got)
+	}
+	if Min(&empty) != nil || Max(&empty) != nil {
+		t.Errorf("Min() and Max() of empty list should be nil")
+	}
+}
+
+func TestAggregate_By(t *testing.T) {
+	list := NewList("pear", "fig", "apple", "kiwi", "melon")
+	length := func(s string) int { return len(s) }
+
+	if got := MinBy(&list, length); got == nil || *got != "fig" {
+		t.Errorf("MinBy() = %v, want fig", got)
+	}
+	// The first of the items with the largest key wins.
+	if got := MaxBy(&list, length); got == nil || *got != "apple" {
+		t.Errorf("MaxBy() = %v, want apple", got)
+	}
+	empty := NewList[string]()
+	if MinBy(&empty, length) != nil || MaxBy(&empty, length) != nil {
+		t.Errorf("MinBy() and MaxBy() of empty list should be nil")
+	}
+}
+
+func TestAggregate_Statistics(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      func(list *List[float64]) float64
+		items    []float64
+		expected float64
+	}{
+		{name: "mean", got: Mean[float64], items: []float64{1, 2, 3, 4}, expected: 2.5},
+		{name: "median of odd length", got: Median[float64], items: []float64{5, 1, 3}, expected: 3},
+		{name: "median of even length", got: Median[float64], items: []float64{4, 1, 3, 2}, expected: 2.5},
+		{name: "variance", got: Variance[float64], items: []float64{2, 4, 4, 4, 5, 5, 7, 9}, expected: 4},
+		{name: "standard deviation", got: StdDev[float64], items: []float64{2, 4, 4, 4, 5, 5, 7, 9}, expected: 2},
+		{name: "variance of one item", got: Variance[float64], items: []float64{3}, expected: 0},
+		{name: "mean of empty list", got: Mean[float64], expected: math.NaN()},
+		{name: "median of empty list", got: Median[float64], expected: math.NaN()},
+		{name: "variance of empty list", got: Variance[float64], expected: math.NaN()},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			list := NewList(tc.items...)
+			got := tc.got(&list)
+			if math.IsNaN(tc.expected) && !math.IsNaN(got) || !math.IsNaN(tc.expected) && math.Abs(got-tc.expected) > 1e-9 {
+				t.Errorf("got %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	list := NewList(50, 15, 40, 20, 35)
+
+	tests := []struct {
+		p        float64
+		expected float64
+	}{
+		{p: 0, expected: 15},
+		{p: 40, expected: 29},
+		{p: 50, expected: 35},
+		{p: 90, expected: 46},
+		{p: 100, expected: 50},
+	}
+
+	for _, tc := range tests {
+		if got := Percentile(&list, tc.p); math.Abs(got-tc.expected) > 1e-9 {
+			t.Errorf("Percentile(%v) = %v, want %v", tc.p, got, tc.expected)
+		}
+	}
+	if got := list.Slice(); !reflect.DeepEqual(got, []int{50, 15, 40, 20, 35}) {
+		t.Errorf("Percentile() changed the list to %v", got)
+	}
+	assertPanics(t, "Percentile(101)", func() { Percentile(&list, 101) })
+	assertPanics(t, "Percentile(NaN)", func() { Percentile(&list, math.NaN()) })
+}
+
+func TestKahanSum(t *testing.T) {
+	list := NewList(1.0, 1e100, 1.0, -1e100)
+	if got := KahanSum(&list); got != 2 {
+		t.Errorf("KahanSum() = %v, want 2", got)
+	}
+
+	tenths := NewList[float64]()
+	for i := 0; i < 1000; i++ {
+		tenths.Add(0.1)
+	}
+	if got := KahanSum(&tenths); got != 100 {
+		t.Errorf("KahanSum() of 1000 * 0.1 = %v, want 100", got)
+	}
+	if got := Sum(&tenths); got == 100 {
+		t.Errorf("Sum() of 1000 * 0.1 is exact, the test does not show the rounding error")
+	}
+
+	small := NewList[float32](0.5, 0.25)
+	if got := KahanSum(&small); got != 0.75 {
+		t.Errorf("KahanSum() of float32 = %v, want 0.75", got)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []int
+		buckets  int
+		expected []HistogramBucket
+	}{
+		{
+			name:     "two buckets",
+			items:    []int{1, 2, 2, 3, 9},
+			buckets:  2,
+			expected: []HistogramBucket{{Lo: 1, Hi: 5, Count: 4}, {Lo: 5, Hi: 9, Count: 1}},
+		},
+		{
+			name:     "the largest item is in the last bucket",
+			items:    []int{0, 10, 5, 10},
+			buckets:  4,
+			expected: []HistogramBucket{{Lo: 0, Hi: 2.5, Count: 1}, {Lo: 2.5, Hi: 5}, {Lo: 5, Hi: 7.5, Count: 1}, {Lo: 7.5, Hi: 10, Count: 2}},
+		},
+		{
+			name:     "equal items",
+			items:    []int{3, 3},
+			buckets:  2,
+			expected: []HistogramBucket{{Lo: 3, Hi: 3, Count: 2}, {Lo: 3, Hi: 3}},
+		},
+		{
+			name:     "empty list",
+			buckets:  3,
+			expected: []HistogramBucket{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			list := NewList(tc.items...)
+			if got := Histogram(&list, tc.buckets).Slice(); !reflect.DeepEqual(append([]HistogramBucket{}, got...), tc.expected) {
+				t.Errorf("Histogram() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+
+	list := NewList(1)
+	assertPanics(t, "Histogram(0)", func() { Histogram(&list, 0) })
+}
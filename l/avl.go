@@ -0,0 +1,80 @@
+package l
+
+// avlLinks holds the children and the height of a node of an AVL tree. The nodes of the AVL trees of the package
+// embed it and share the balancing code below.
+type avlLinks[N any] struct {
+	left   N
+	right  N
+	height int
+}
+
+// avlNode is implemented by the pointers to the nodes of an AVL tree.
+type avlNode[N any] interface {
+	comparable
+	links() *avlLinks[N]
+	// update recomputes the fields of the node that are derived from its children, other than the height.
+	update()
+}
+
+func avlHeight[N avlNode[N]](node N) int {
+	var none N
+	if node == none {
+		return 0
+	}
+	return node.links().height
+}
+
+func avlUpdate[N avlNode[N]](node N) {
+	links := node.links()
+	links.height = 1 + max(avlHeight(links.left), avlHeight(links.right))
+	node.update()
+}
+
+func avlRotateLeft[N avlNode[N]](node N) N {
+	right := node.links().right
+	node.links().right = right.links().left
+	right.links().left = node
+	avlUpdate(node)
+	avlUpdate(right)
+	return right
+}
+
+func avlRotateRight[N avlNode[N]](node N) N {
+	left := node.links().left
+	node.links().left = left.links().right
+	left.links().right = node
+	avlUpdate(node)
+	avlUpdate(left)
+	return left
+}
+
+// avlBalance restores the AVL invariant of a node whose subtrees differ in height by at most two.
+func avlBalance[N avlNode[N]](node N) N {
+	avlUpdate(node)
+	links := node.links()
+	switch balance := avlHeight(links.left) - avlHeight(links.right); {
+	case balance > 1:
+		if avlHeight(links.left.links().left) < avlHeight(links.left.links().right) {
+			links.left = avlRotateLeft(links.left)
+		}
+		return avlRotateRight(node)
+	case balance < -1:
+		if avlHeight(links.right.links().right) < avlHeight(links.right.links().left) {
+			links.right = avlRotateRight(links.right)
+		}
+		return avlRotateLeft(node)
+	}
+	return node
+}
+
+// avlRemoveMin detaches the smallest node from the subtree and returns the new subtree and the node.
+func avlRemoveMin[N avlNode[N]](node N) (N, N) {
+	var none N
+	links := node.links()
+	if links.left == none {
+		return links.right, node
+	}
+	var smallest N
+	links.left, smallest = avlRemoveMin(links.left)
+	return avlBalance(node), smallest
+}
@@ -0,0 +1,142 @@
+package l
+
+import "errors"
+
+// ErrDuplicateValue is returned by BiMap.Put when the value is already mapped to another key.
+var ErrDuplicateValue = errors.New("l: value is already mapped to another key")
+
+// BiMap is a bidirectional map in which both the keys and the values are unique.
+// A value can be looked up by its key and a key by its value, both in O(1).
+type BiMap[K comparable, V comparable] struct {
+	forward map[K]V
+	inverse map[V]K
+}
+
+// NewBiMap creates an empty BiMap.
+//
+// Example usage:
+//
+//	m := NewBiMap[string, int]()
+//	m.Put("one", 1)
+//	*m.Get("one") // 1
+//	*m.GetKey(1) // "one"
+//	m.Put("uno", 1) // ErrDuplicateValue
+func NewBiMap[K comparable, V comparable]() BiMap[K, V] {
+	return BiMap[K, V]{forward: map[K]V{}, inverse: map[V]K{}}
+}
+
+// Put maps the key to the value, replacing the previous value of the key.
+// It returns ErrDuplicateValue and leaves the map unchanged if the value is already mapped to another key.
+func (m *BiMap[K, V]) Put(key K, value V) error {
+	if other, ok := m.inverse[value]; ok && other != key {
+		return ErrDuplicateValue
+	}
+	m.ForcePut(key, value)
+	return nil
+}
+
+// ForcePut maps the key to the value. If the value is already mapped to another key, that key is removed first.
+func (m *BiMap[K, V]) ForcePut(key K, value V) {
+	if m.forward == nil {
+		m.forward, m.inverse = map[K]V{}, map[V]K{}
+	}
+
+	m.RemoveValue(value)
+	m.RemoveKey(key)
+	m.forward[key] = value
+	m.inverse[value] = key
+}
+
+// Get returns a pointer to the value mapped to the key. If the key is not in the map, it returns nil.
+func (m *BiMap[K, V]) Get(key K) *V {
+	value, ok := m.forward[key]
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+// GetKey returns a pointer to the key mapped to the value. If the value is not in the map, it returns nil.
+func (m *BiMap[K, V]) GetKey(value V) *K {
+	key, ok := m.inverse[value]
+	if !ok {
+		return nil
+	}
+	return &key
+}
+
+// ContainsKey returns true if the key is in the map.
+func (m *BiMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.forward[key]
+	return ok
+}
+
+// ContainsValue returns true if the value is in the map.
+func (m *BiMap[K, V]) ContainsValue(value V) bool {
+	_, ok := m.inverse[value]
+	return ok
+}
+
+// RemoveKey removes the key and its value. It returns true if the key was in the map.
+func (m *BiMap[K, V]) RemoveKey(key K) bool {
+	value, ok := m.forward[key]
+	if ok {
+		delete(m.forward, key)
+		delete(m.inverse, value)
+	}
+	return ok
+}
+
+// RemoveValue removes the value and its key. It returns true if the value was in the map.
+func (m *BiMap[K, V]) RemoveValue(value V) bool {
+	key, ok := m.inverse[value]
+	if ok {
+		delete(m.inverse, value)
+		delete(m.forward, key)
+	}
+	return ok
+}
+
+// Length returns the number of pairs in the map.
+func (m *BiMap[K, V]) Length() int {
+	return len(m.forward)
+}
+
+// IsEmpty returns true if the map has no pairs, false otherwise.
+func (m *BiMap[K, V]) IsEmpty() bool {
+	return len(m.forward) == 0
+}
+
+// ForEach calls f with every key and value pair, in no particular order.
+func (m *BiMap[K, V]) ForEach(f func(key K, value V)) {
+	for key, value := range m.forward {
+		f(key, value)
+	}
+}
+
+// All returns a function that calls yield with every key and value pair in no particular order,
+// until yield returns false.
+func (m *BiMap[K, V]) All() func(yield func(Entry[K, V]) bool) {
+	return func(yield func(Entry[K, V]) bool) {
+		for key, value := range m.forward {
+			if !yield(Entry[K, V]{Key: key, Value: value}) {
+				return
+			}
+		}
+	}
+}
+
+// Inverse returns a view of the map with the keys and values swapped. The view shares its data with the map,
+// so changes to one are visible in the other.
+func (m *BiMap[K, V]) Inverse() BiMap[V, K] {
+	if m.forward == nil {
+		m.forward, m.inverse = map[K]V{}, map[V]K{}
+	}
+	return BiMap[V, K]{forward: m.inverse, inverse: m.forward}
+}
+
+// Clear removes all pairs from the map.
+func (m *BiMap[K, V]) Clear() {
+	clear(m.forward)
+	clear(m.inverse)
+}
@@ -0,0 +1,87 @@
+package l
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBiMap_Put(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   int
+		force   bool
+		wantErr error
+		want    map[string]int
+	}{
+		{name: "new pair", key: "c", value: 3, want: map[string]int{"a": 1, "b": 2, "c": 3}},
+		{name: "same pair", key: "a", value: 1, want: map[string]int{"a": 1, "b": 2}},
+		{name: "new value for key", key: "a", value: 9, want: map[string]int{"a": 9, "b": 2}},
+		{name: "duplicate value", key: "c", value: 2, wantErr: ErrDuplicateValue, want: map[string]int{"a": 1, "b": 2}},
+		{name: "forced duplicate value", key: "c", value: 2, force: true, want: map[string]int{"a": 1, "c": 2}},
+		{name: "forced swap", key: "a", value: 2, force: true, want: map[string]int{"a": 2}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewBiMap[string, int]()
+			m.Put("a", 1)
+			m.Put("b", 2)
+
+			if tc.force {
+				m.ForcePut(tc.key, tc.value)
+			} else if err := m.Put(tc.key, tc.value); !errors.Is(err, tc.wantErr) {
+				t.Errorf("Put() error = %v, want %v", err, tc.wantErr)
+			}
+
+			if m.Length() != len(tc.want) {
+				t.Errorf("Length() = %d, want %d", m.Length(), len(tc.want))
+			}
+			for key, value := range tc.want {
+				if got := m.Get(key); got == nil || *got != value {
+					t.Errorf("Get(%q) = %v, want %d", key, got, value)
+				}
+				if got := m.GetKey(value); got == nil || *got != key {
+					t.Errorf("GetKey(%d) = %v, want %q", value, got, key)
+				}
+			}
+		})
+	}
+}
+
+func TestBiMap_RemoveInverse(t *testing.T) {
+	var m BiMap[string, int]
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	if !m.RemoveKey("a") || m.RemoveKey("a") || m.ContainsValue(1) {
+		t.Errorf("RemoveKey() returned a wrong result")
+	}
+	if !m.RemoveValue(2) || m.RemoveValue(2) || m.ContainsKey("b") {
+		t.Errorf("RemoveValue() returned a wrong result")
+	}
+	if m.Get("a") != nil || m.GetKey(2) != nil {
+		t.Errorf("removed pairs are still in the map")
+	}
+
+	inverse := m.Inverse()
+	if key := inverse.Get(3); key == nil || *key != "c" {
+		t.Errorf("Inverse().Get(3) = %v, want c", key)
+	}
+	inverse.Put(4, "d")
+	if value := m.Get("d"); value == nil || *value != 4 {
+		t.Errorf("change of the inverse view is not visible in the map")
+	}
+
+	count := 0
+	m.ForEach(func(string, int) { count++ })
+	if count != 2 {
+		t.Errorf("ForEach() visited %d pairs, want 2", count)
+	}
+
+	m.Clear()
+	if !m.IsEmpty() || !inverse.IsEmpty() {
+		t.Errorf("map not empty after Clear")
+	}
+}
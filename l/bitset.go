@@ -0,0 +1,250 @@
+package l
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+const bitSetTag byte = 'S'
+
+// BitSet is a set of non-negative integers stored as a bit vector, using one bit per possible member.
+// It grows automatically when a bit beyond its current size is set. The zero value is an empty set ready to use.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet creates a BitSet with the given bits set.
+//
+// Example usage:
+//
+//	b := NewBitSet(1, 3, 5)
+//	b.Test(3) // true
+//	b.Count() // 3
+//	b.NextSet(2) // 3
+func NewBitSet(indexes ...int) BitSet {
+	b := BitSet{}
+	for _, i := range indexes {
+		b.Set(i)
+	}
+	return b
+}
+
+// Set sets the bit at the given index, growing the set if necessary. It panics if the index is negative.
+func (b *BitSet) Set(index int) {
+	word := b.grow(index)
+	b.words[word] |= 1 << (index % 64)
+}
+
+// Reset clears the bit at the given index.
+func (b *BitSet) Reset(index int) {
+	if word := index / 64; index >= 0 && word < len(b.words) {
+		b.words[word] &^= 1 << (index % 64)
+	}
+}
+
+// Test returns true if the bit at the given index is set.
+func (b *BitSet) Test(index int) bool {
+	word := index / 64
+	return index >= 0 && word < len(b.words) && b.words[word]&(1<<(index%64)) != 0
+}
+
+// Flip inverts the bit at the given index, growing the set if necessary.
+func (b *BitSet) Flip(index int) {
+	word := b.grow(index)
+	b.words[word] ^= 1 << (index % 64)
+}
+
+// Count returns the number of set bits.
+func (b *BitSet) Count() int {
+	count := 0
+	for _, word := range b.words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// Length returns the number of set bits, like Count.
+func (b *BitSet) Length() int {
+	return b.Count()
+}
+
+// Size returns the number of bits the set can hold without growing.
+func (b *BitSet) Size() int {
+	return len(b.words) * 64
+}
+
+// IsEmpty returns true if no bit is set, false otherwise.
+func (b *BitSet) IsEmpty() bool {
+	for _, word := range b.words {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clear clears all bits and releases the memory of the set.
+func (b *BitSet) Clear() {
+	b.words = nil
+}
+
+// NextSet returns the index of the first set bit at or after the given index. If there is none, it returns -1.
+func (b *BitSet) NextSet(from int) int {
+	from = max(from, 0)
+	word := from / 64
+	if word >= len(b.words) {
+		return -1
+	}
+
+	current := b.words[word] >> (from % 64)
+	if current != 0 {
+		return from + bits.TrailingZeros64(current)
+	}
+	for word++; word < len(b.words); word++ {
+		if b.words[word] != 0 {
+			return word*64 + bits.TrailingZeros64(b.words[word])
+		}
+	}
+	return -1
+}
+
+// NextClear returns the index of the first clear bit at or after the given index.
+// Since the set grows on demand, there is always such a bit.
+func (b *BitSet) NextClear(from int) int {
+	from = max(from, 0)
+	word := from / 64
+	if word >= len(b.words) {
+		return from
+	}
+
+	current := ^b.words[word] >> (from % 64)
+	if current != 0 {
+		return from + bits.TrailingZeros64(current)
+	}
+	for word++; word < len(b.words); word++ {
+		if b.words[word] != ^uint64(0) {
+			return word*64 + bits.TrailingZeros64(^b.words[word])
+		}
+	}
+	return len(b.words) * 64
+}
+
+// ForEach calls f with the index of every set bit in ascending order.
+func (b *BitSet) ForEach(f func(index int)) {
+	for word, value := range b.words {
+		for value != 0 {
+			f(word*64 + bits.TrailingZeros64(value))
+			value &= value - 1
+		}
+	}
+}
+
+// All returns a function that calls yield with the index of every set bit in ascending order, until yield
+// returns false.
+func (b *BitSet) All() func(yield func(int) bool) {
+	return func(yield func(int) bool) {
+		for word, value := range b.words {
+			for value != 0 {
+				if !yield(word*64 + bits.TrailingZeros64(value)) {
+					return
+				}
+				value &= value - 1
+			}
+		}
+	}
+}
+
+// Indexes returns a List with the indexes of all set bits in ascending order.
+func (b *BitSet) Indexes() *List[int] {
+	indexes := NewList[int]()
+	b.ForEach(func(index int) { indexes.Add(index) })
+	return &indexes
+}
+
+// Equal returns true if both sets contain the same bits, regardless of their size.
+func (b *BitSet) Equal(other *BitSet) bool {
+	for i := 0; i < max(len(b.words), len(other.words)); i++ {
+		if b.word(i) != other.word(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a copy of the set.
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+// And returns a new set with the bits that are set in both sets.
+func (b *BitSet) And(other *BitSet) *BitSet {
+	return b.combine(other, min(len(b.words), len(other.words)), func(x, y uint64) uint64 { return x & y })
+}
+
+// Or returns a new set with the bits that are set in either set.
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	return b.combine(other, max(len(b.words), len(other.words)), func(x, y uint64) uint64 { return x | y })
+}
+
+// Xor returns a new set with the bits that are set in exactly one of the sets.
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	return b.combine(other, max(len(b.words), len(other.words)), func(x, y uint64) uint64 { return x ^ y })
+}
+
+// AndNot returns a new set with the bits that are set in b but not in other.
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	return b.combine(other, len(b.words), func(x, y uint64) uint64 { return x &^ y })
+}
+
+// MarshalBinary encodes the set into a binary form.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	data := marshalHeader(bitSetTag, uint64(len(b.words)))
+	for _, word := range b.words {
+		data = binary.BigEndian.AppendUint64(data, word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary restores a set encoded by MarshalBinary.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	params, data, err := unmarshalHeader(data, bitSetTag, 1)
+	if err != nil {
+		return err
+	}
+	if params[0] > uint64(len(data))/8 || uint64(len(data)) != params[0]*8 {
+		return ErrInvalidData
+	}
+
+	b.words = make([]uint64, params[0])
+	for i := range b.words {
+		b.words[i] = binary.BigEndian.Uint64(data[8*i:])
+	}
+	return nil
+}
+
+// grow makes sure the set can hold the bit at the index and returns the index of its word.
+func (b *BitSet) grow(index int) int {
+	if index < 0 {
+		panic("l: negative BitSet index")
+	}
+	word := index / 64
+	if word >= len(b.words) {
+		b.words = append(b.words, make([]uint64, word+1-len(b.words))...)
+	}
+	return word
+}
+
+func (b *BitSet) word(i int) uint64 {
+	if i < len(b.words) {
+		return b.words[i]
+	}
+	return 0
+}
+
+func (b *BitSet) combine(other *BitSet, length int, op func(x, y uint64) uint64) *BitSet {
+	result := &BitSet{words: make([]uint64, length)}
+	for i := range result.words {
+		result.words[i] = op(b.word(i), other.word(i))
+	}
+	return result
+}
@@ -0,0 +1,140 @@
+package l
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBitSet_SetClearFlip(t *testing.T) {
+	tests := []struct {
+		name  string
+		set   []int
+		clear []int
+		flip  []int
+		want  []int
+	}{
+		{name: "empty set", want: []int{}},
+		{name: "set grows", set: []int{0, 63, 64, 1000}, want: []int{0, 63, 64, 1000}},
+		{name: "clear", set: []int{1, 2, 3}, clear: []int{2, 5000}, want: []int{1, 3}},
+		{name: "flip", set: []int{1, 2}, flip: []int{2, 130}, want: []int{1, 130}},
+		{name: "duplicate set", set: []int{7, 7}, want: []int{7}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewBitSet(tc.set...)
+			for _, i := range tc.clear {
+				b.Reset(i)
+			}
+			for _, i := range tc.flip {
+				b.Flip(i)
+			}
+
+			if got := b.Indexes().Slice(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Indexes() = %v, want %v", got, tc.want)
+			}
+			if b.Count() != len(tc.want) {
+				t.Errorf("Count() = %d, want %d", b.Count(), len(tc.want))
+			}
+			for _, i := range tc.want {
+				if !b.Test(i) {
+					t.Errorf("Test(%d) = false, want true", i)
+				}
+			}
+			if b.Test(-1) || b.Test(1<<20) {
+				t.Errorf("Test() outside of the set = true, want false")
+			}
+		})
+	}
+}
+
+func TestBitSet_Next(t *testing.T) {
+	b := NewBitSet(0, 1, 2, 64, 130)
+
+	tests := []struct {
+		from      int
+		nextSet   int
+		nextClear int
+	}{
+		{from: 0, nextSet: 0, nextClear: 3},
+		{from: 3, nextSet: 64, nextClear: 3},
+		{from: 64, nextSet: 64, nextClear: 65},
+		{from: 131, nextSet: -1, nextClear: 131},
+		{from: 500, nextSet: -1, nextClear: 500},
+		{from: -5, nextSet: 0, nextClear: 3},
+	}
+
+	for _, tc := range tests {
+		if got := b.NextSet(tc.from); got != tc.nextSet {
+			t.Errorf("NextSet(%d) = %d, want %d", tc.from, got, tc.nextSet)
+		}
+		if got := b.NextClear(tc.from); got != tc.nextClear {
+			t.Errorf("NextClear(%d) = %d, want %d", tc.from, got, tc.nextClear)
+		}
+	}
+
+	full := BitSet{}
+	for i := 0; i < 128; i++ {
+		full.Set(i)
+	}
+	if got := full.NextClear(0); got != 128 {
+		t.Errorf("NextClear() of full set = %d, want 128", got)
+	}
+}
+
+func TestBitSet_Operations(t *testing.T) {
+	a := NewBitSet(1, 2, 3, 100)
+	b := NewBitSet(2, 3, 4)
+
+	tests := []struct {
+		name string
+		got  *BitSet
+		want []int
+	}{
+		{name: "and", got: a.And(&b), want: []int{2, 3}},
+		{name: "or", got: a.Or(&b), want: []int{1, 2, 3, 4, 100}},
+		{name: "xor", got: a.Xor(&b), want: []int{1, 4, 100}},
+		{name: "and not", got: a.AndNot(&b), want: []int{1, 100}},
+		{name: "reverse and not", got: b.AndNot(&a), want: []int{4}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.got.Indexes().Slice(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("result = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if got := a.Indexes().Slice(); !reflect.DeepEqual(got, []int{1, 2, 3, 100}) {
+		t.Errorf("operations modified the receiver: %v", got)
+	}
+	if !a.Equal(a.Or(&BitSet{})) || a.Equal(&b) {
+		t.Errorf("Equal() returned a wrong result")
+	}
+}
+
+func TestBitSet_Marshal(t *testing.T) {
+	b := NewBitSet(0, 5, 64, 999)
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	var restored BitSet
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if !restored.Equal(&b) {
+		t.Errorf("restored set = %v, want %v", restored.Indexes().Slice(), b.Indexes().Slice())
+	}
+	if err := restored.UnmarshalBinary(data[:len(data)-3]); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary() of truncated data error = %v, want %v", err, ErrInvalidData)
+	}
+
+	b.Clear()
+	if !b.IsEmpty() || b.Size() != 0 {
+		t.Errorf("set not empty after Clear")
+	}
+}
@@ -0,0 +1,279 @@
+package l
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+)
+
+const (
+	bloomFilterTag         byte = 'B'
+	countingBloomFilterTag byte = 'C'
+
+	bloomDefaultFalsePositiveRate = 0.01
+	// bloomMaxHashCount bounds the number of hash functions. It is reached at a false-positive rate of about 2^-64,
+	// and it keeps a filter restored from crafted data from hashing an item billions of times.
+	bloomMaxHashCount = 64
+)
+
+// BloomFilter is a probabilistic set. Contains never reports a false negative, but it reports an item that
+// was not added with a probability close to the configured false-positive rate, as long as no more than the
+// expected number of items is added. The filter uses a fixed amount of memory regardless of the size of the items.
+//
+// Example usage:
+//
+//	seen := NewBloomFilter[string](1000000, 0.001)
+//	seen.Add("a")
+//	seen.Contains("a") // true
+//	seen.Contains("b") // false, with probability 0.999
+type BloomFilter[T any] struct {
+	bits   []uint64
+	m      uint64
+	k      int
+	hasher Hasher[T]
+}
+
+// NewBloomFilter creates a BloomFilter sized for the expected number of items and the false-positive rate,
+// using DefaultHasher. An expected count below 1 is treated as 1 and a rate outside (0, 1) as 0.01.
+func NewBloomFilter[T any](expected int, falsePositiveRate float64) *BloomFilter[T] {
+	return NewBloomFilterWithHasher(expected, falsePositiveRate, DefaultHasher[T]())
+}
+
+// NewBloomFilterWithHasher creates a BloomFilter like NewBloomFilter that hashes the items with the given hasher.
+func NewBloomFilterWithHasher[T any](expected int, falsePositiveRate float64, hasher Hasher[T]) *BloomFilter[T] {
+	m, k := bloomParameters(expected, falsePositiveRate)
+	return &BloomFilter[T]{
+		bits:   make([]uint64, (m+63)/64),
+		m:      m,
+		k:      k,
+		hasher: hasher,
+	}
+}
+
+// Add adds items to the filter.
+func (b *BloomFilter[T]) Add(item ...T) {
+	for _, i := range item {
+		hashIndexes(b.hash(i), b.k, b.m, func(index uint64) {
+			b.bits[index/64] |= 1 << (index % 64)
+		})
+	}
+}
+
+// Contains returns true if the item may have been added to the filter, and false if it has certainly not been added.
+func (b *BloomFilter[T]) Contains(item T) bool {
+	found := true
+	hashIndexes(b.hash(item), b.k, b.m, func(index uint64) {
+		found = found && b.bits[index/64]&(1<<(index%64)) != 0
+	})
+	return found
+}
+
+// EstimatedCount returns an estimate of the number of distinct items added to the filter.
+func (b *BloomFilter[T]) EstimatedCount() int {
+	set := 0
+	for _, word := range b.bits {
+		set += bits.OnesCount64(word)
+	}
+	if uint64(set) == b.m {
+		return math.MaxInt
+	}
+	m := float64(b.m)
+	return int(math.Round(-m / float64(b.k) * math.Log(1-float64(set)/m)))
+}
+
+// Size returns the number of bits of the filter.
+func (b *BloomFilter[T]) Size() int {
+	return int(b.m)
+}
+
+// HashCount returns the number of hash functions applied to every item.
+func (b *BloomFilter[T]) HashCount() int {
+	return b.k
+}
+
+// Clear removes all items from the filter.
+func (b *BloomFilter[T]) Clear() {
+	clear(b.bits)
+}
+
+// Merge adds all items of other to the filter. Both filters must have the same size and hash count, and they must
+// use the same hasher. It returns ErrIncompatible if the sizes or hash counts differ.
+func (b *BloomFilter[T]) Merge(other *BloomFilter[T]) error {
+	if b.m != other.m || b.k != other.k {
+		return ErrIncompatible
+	}
+	for i, word := range other.bits {
+		b.bits[i] |= word
+	}
+	return nil
+}
+
+// MarshalBinary encodes the filter into a binary form. The hasher is not encoded.
+func (b *BloomFilter[T]) MarshalBinary() ([]byte, error) {
+	data := marshalHeader(bloomFilterTag, b.m, uint64(b.k))
+	for _, word := range b.bits {
+		data = binary.BigEndian.AppendUint64(data, word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary restores a filter encoded by MarshalBinary. The hasher of the filter is kept,
+// or DefaultHasher is used if the filter has none.
+func (b *BloomFilter[T]) UnmarshalBinary(data []byte) error {
+	params, data, err := unmarshalHeader(data, bloomFilterTag, 2)
+	if err != nil {
+		return err
+	}
+	m, k := params[0], params[1]
+	// Compare m with the payload before rounding it up to words, which may overflow.
+	if m == 0 || m > uint64(len(data))*8 || k == 0 || k > bloomMaxHashCount || uint64(len(data)) != (m+63)/64*8 {
+		return ErrInvalidData
+	}
+	words := (m + 63) / 64
+
+	b.bits = make([]uint64, words)
+	for i := range b.bits {
+		b.bits[i] = binary.BigEndian.Uint64(data[8*i:])
+	}
+	b.m = m
+	b.k = int(k)
+	return nil
+}
+
+func (b *BloomFilter[T]) hash(item T) uint64 {
+	if b.hasher == nil {
+		b.hasher = DefaultHasher[T]()
+	}
+	return b.hasher(item)
+}
+
+// CountingBloomFilter is a BloomFilter that keeps a small counter instead of a single bit per position,
+// so items can also be removed. Counters saturate at 255 and are never decremented once saturated.
+type CountingBloomFilter[T any] struct {
+	counters []uint8
+	k        int
+	hasher   Hasher[T]
+}
+
+// NewCountingBloomFilter creates a CountingBloomFilter sized for the expected number of items and the
+// false-positive rate, using DefaultHasher.
+func NewCountingBloomFilter[T any](expected int, falsePositiveRate float64) *CountingBloomFilter[T] {
+	return NewCountingBloomFilterWithHasher(expected, falsePositiveRate, DefaultHasher[T]())
+}
+
+// NewCountingBloomFilterWithHasher creates a CountingBloomFilter like NewCountingBloomFilter that hashes the items
+// with the given hasher.
+func NewCountingBloomFilterWithHasher[T any](expected int, falsePositiveRate float64, hasher Hasher[T]) *CountingBloomFilter[T] {
+	m, k := bloomParameters(expected, falsePositiveRate)
+	return &CountingBloomFilter[T]{
+		counters: make([]uint8, m),
+		k:        k,
+		hasher:   hasher,
+	}
+}
+
+// Add adds items to the filter.
+func (b *CountingBloomFilter[T]) Add(item ...T) {
+	for _, i := range item {
+		b.indexes(i, func(index uint64) {
+			if b.counters[index] < math.MaxUint8 {
+				b.counters[index]++
+			}
+		})
+	}
+}
+
+// Remove removes one occurrence of the item from the filter. It returns false, and leaves the filter unchanged,
+// if the item has certainly not been added. Removing an item that was never added may cause false negatives.
+func (b *CountingBloomFilter[T]) Remove(item T) bool {
+	if !b.Contains(item) {
+		return false
+	}
+	b.indexes(item, func(index uint64) {
+		if b.counters[index] < math.MaxUint8 {
+			b.counters[index]--
+		}
+	})
+	return true
+}
+
+// Contains returns true if the item may be in the filter, and false if it is certainly not in the filter.
+func (b *CountingBloomFilter[T]) Contains(item T) bool {
+	found := true
+	b.indexes(item, func(index uint64) {
+		found = found && b.counters[index] > 0
+	})
+	return found
+}
+
+// Size returns the number of counters of the filter.
+func (b *CountingBloomFilter[T]) Size() int {
+	return len(b.counters)
+}
+
+// HashCount returns the number of hash functions applied to every item.
+func (b *CountingBloomFilter[T]) HashCount() int {
+	return b.k
+}
+
+// Clear removes all items from the filter.
+func (b *CountingBloomFilter[T]) Clear() {
+	clear(b.counters)
+}
+
+// Merge adds all items of other to the filter. It returns ErrIncompatible if the sizes or hash counts differ.
+func (b *CountingBloomFilter[T]) Merge(other *CountingBloomFilter[T]) error {
+	if len(b.counters) != len(other.counters) || b.k != other.k {
+		return ErrIncompatible
+	}
+	for i, count := range other.counters {
+		b.counters[i] = uint8(min(int(b.counters[i])+int(count), math.MaxUint8))
+	}
+	return nil
+}
+
+// MarshalBinary encodes the filter into a binary form. The hasher is not encoded.
+func (b *CountingBloomFilter[T]) MarshalBinary() ([]byte, error) {
+	data := marshalHeader(countingBloomFilterTag, uint64(len(b.counters)), uint64(b.k))
+	return append(data, b.counters...), nil
+}
+
+// UnmarshalBinary restores a filter encoded by MarshalBinary. The hasher of the filter is kept,
+// or DefaultHasher is used if the filter has none.
+func (b *CountingBloomFilter[T]) UnmarshalBinary(data []byte) error {
+	params, data, err := unmarshalHeader(data, countingBloomFilterTag, 2)
+	if err != nil {
+		return err
+	}
+	m, k := params[0], params[1]
+	if m == 0 || k == 0 || k > bloomMaxHashCount || uint64(len(data)) != m {
+		return ErrInvalidData
+	}
+
+	b.counters = append([]uint8(nil), data...)
+	b.k = int(k)
+	return nil
+}
+
+func (b *CountingBloomFilter[T]) indexes(item T, f func(index uint64)) {
+	if b.hasher == nil {
+		b.hasher = DefaultHasher[T]()
+	}
+	hashIndexes(b.hasher(item), b.k, uint64(len(b.counters)), f)
+}
+
+// bloomParameters returns the optimal number of bits and hash functions for the expected number of items and
+// the false-positive rate.
+func bloomParameters(expected int, falsePositiveRate float64) (uint64, int) {
+	if expected < 1 {
+		expected = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = bloomDefaultFalsePositiveRate
+	}
+
+	n := float64(expected)
+	m := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := int(math.Round(m / n * math.Ln2))
+	return uint64(max(m, 1)), min(max(k, 1), bloomMaxHashCount)
+}
@@ -0,0 +1,195 @@
+package l
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBloomFilter_FalsePositiveRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected int
+		rate     float64
+	}{
+		{name: "one percent", expected: 1000, rate: 0.01},
+		{name: "one per mille", expected: 5000, rate: 0.001},
+		{name: "invalid rate uses default", expected: 1000, rate: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewBloomFilter[string](tc.expected, tc.rate)
+			for i := 0; i < tc.expected; i++ {
+				b.Add(fmt.Sprintf("item-%d", i))
+			}
+			for i := 0; i < tc.expected; i++ {
+				if !b.Contains(fmt.Sprintf("item-%d", i)) {
+					t.Fatalf("Contains(item-%d) = false, want true", i)
+				}
+			}
+
+			rate := tc.rate
+			if rate >= 1 {
+				rate = 0.01
+			}
+			falsePositives := 0
+			const probes = 20000
+			for i := 0; i < probes; i++ {
+				if b.Contains(fmt.Sprintf("other-%d", i)) {
+					falsePositives++
+				}
+			}
+			if got := float64(falsePositives) / probes; got > 2*rate {
+				t.Errorf("false-positive rate = %v, want at most %v", got, 2*rate)
+			}
+
+			count := b.EstimatedCount()
+			if count < tc.expected*9/10 || count > tc.expected*11/10 {
+				t.Errorf("EstimatedCount() = %d, want about %d", count, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBloomFilter_MergeMarshal(t *testing.T) {
+	a := NewBloomFilter[int](100, 0.01)
+	b := NewBloomFilter[int](100, 0.01)
+	a.Add(1, 2, 3)
+	b.Add(4, 5)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if err := a.Merge(NewBloomFilter[int](10, 0.01)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Merge() of different size error = %v, want %v", err, ErrIncompatible)
+	}
+
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	var restored BloomFilter[int]
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	for i := 1; i <= 5; i++ {
+		if !restored.Contains(i) {
+			t.Errorf("restored Contains(%d) = false, want true", i)
+		}
+	}
+	if restored.Size() != a.Size() || restored.HashCount() != a.HashCount() {
+		t.Errorf("restored parameters = %d/%d, want %d/%d", restored.Size(), restored.HashCount(), a.Size(), a.HashCount())
+	}
+	if err := restored.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary() of truncated data error = %v, want %v", err, ErrInvalidData)
+	}
+
+	a.Clear()
+	if a.Contains(1) {
+		t.Errorf("Contains(1) after Clear = true, want false")
+	}
+}
+
+func TestBloomFilter_Hasher(t *testing.T) {
+	// A hasher that ignores the case makes the filter case-insensitive.
+	hash := DefaultHasher[string]()
+	b := NewBloomFilterWithHasher[string](10, 0.01, func(item string) uint64 {
+		return hash(strings.ToLower(item))
+	})
+	b.Add("Go")
+	if !b.Contains("GO") || !b.Contains("go") {
+		t.Errorf("Contains() with case-insensitive hasher = false, want true")
+	}
+}
+
+func TestCountingBloomFilter(t *testing.T) {
+	b := NewCountingBloomFilter[string](1000, 0.01)
+	b.Add("a", "b", "b")
+
+	tests := []struct {
+		name       string
+		remove     string
+		wantRemove bool
+		contains   map[string]bool
+	}{
+		{name: "remove missing", remove: "c", wantRemove: false, contains: map[string]bool{"a": true, "b": true, "c": false}},
+		{name: "remove one of two", remove: "b", wantRemove: true, contains: map[string]bool{"a": true, "b": true}},
+		{name: "remove last", remove: "b", wantRemove: true, contains: map[string]bool{"a": true, "b": false}},
+		{name: "remove single", remove: "a", wantRemove: true, contains: map[string]bool{"a": false}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := b.Remove(tc.remove); got != tc.wantRemove {
+				t.Errorf("Remove(%q) = %v, want %v", tc.remove, got, tc.wantRemove)
+			}
+			for item, want := range tc.contains {
+				if got := b.Contains(item); got != want {
+					t.Errorf("Contains(%q) = %v, want %v", item, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCountingBloomFilter_MergeMarshal(t *testing.T) {
+	a := NewCountingBloomFilter[int](100, 0.01)
+	b := NewCountingBloomFilter[int](100, 0.01)
+	a.Add(1)
+	b.Add(1, 2)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	data, _ := a.MarshalBinary()
+	var restored CountingBloomFilter[int]
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+
+	// 1 was added twice, so it survives a single removal.
+	restored.Remove(1)
+	if !restored.Contains(1) || !restored.Contains(2) {
+		t.Errorf("restored filter lost items")
+	}
+	if err := restored.UnmarshalBinary([]byte{'B'}); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary() of foreign data error = %v, want %v", err, ErrInvalidData)
+	}
+}
+
+func TestBloomFilter_UnmarshalBinary_Invalid(t *testing.T) {
+	words := func(n int) []byte { return make([]byte, 8*n) }
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "no hash functions", data: append(marshalHeader(bloomFilterTag, 64, 0), words(1)...)},
+		{name: "too many hash functions", data: append(marshalHeader(bloomFilterTag, 64, 1<<31), words(1)...)},
+		{name: "more bits than the payload", data: append(marshalHeader(bloomFilterTag, 129, 3), words(2)...)},
+		{name: "fewer bits than the payload", data: append(marshalHeader(bloomFilterTag, 64, 3), words(2)...)},
+		{name: "bit count that overflows", data: append(marshalHeader(bloomFilterTag, 1<<64-1, 3), words(1)...)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var b BloomFilter[int]
+			if err := b.UnmarshalBinary(tc.data); !errors.Is(err, ErrInvalidData) {
+				t.Errorf("UnmarshalBinary() error = %v, want %v", err, ErrInvalidData)
+			}
+		})
+	}
+
+	var c CountingBloomFilter[int]
+	if err := c.UnmarshalBinary(append(marshalHeader(countingBloomFilterTag, 4, 1<<40), 0, 0, 0, 0)); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary() with too many hash functions error = %v, want %v", err, ErrInvalidData)
+	}
+	if err := c.UnmarshalBinary(append(marshalHeader(countingBloomFilterTag, 5, 3), 0, 0, 0, 0)); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary() with a short payload error = %v, want %v", err, ErrInvalidData)
+	}
+	if k := NewBloomFilter[int](10, 1e-300).HashCount(); k != bloomMaxHashCount {
+		t.Errorf("HashCount() for a tiny rate = %d, want %d", k, bloomMaxHashCount)
+	}
+}
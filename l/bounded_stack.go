@@ -0,0 +1,200 @@
+package l
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// OverflowPolicy selects what a BoundedStack does when an item is pushed onto a full stack.
+type OverflowPolicy int
+
+const (
+	// OverflowReject rejects the new item: PushE returns an OverflowError and Push panics with it.
+	OverflowReject OverflowPolicy = iota
+	// OverflowDropOldest removes the item at the bottom of the stack to make room for the new item.
+	OverflowDropOldest
+	// OverflowBlock waits until an item is popped.
+	OverflowBlock
+)
+
+// OverflowError is returned by BoundedStack.PushE when the stack is full and its policy is OverflowReject.
+type OverflowError struct {
+	// Capacity is the capacity of the full stack.
+	Capacity int
+}
+
+func (e *OverflowError) Error() string {
+	return "l: stack is full at capacity " + strconv.Itoa(e.Capacity)
+}
+
+// BoundedStack is a stack that holds at most a fixed number of items. What happens when an item is pushed onto
+// a full stack depends on its OverflowPolicy. The items are kept in a ring buffer, so dropping the oldest item
+// runs in O(1). A BoundedStack is safe for concurrent use.
+type BoundedStack[T any] struct {
+	mu     sync.Mutex
+	policy OverflowPolicy
+	// The items are items[bottom], items[bottom+1], ... up to length items, wrapping around the end of the slice.
+	items  []T
+	bottom int
+	length int
+	// popped is closed and replaced whenever items are removed, to wake up the blocked pushers.
+	popped chan struct{}
+}
+
+// NewBoundedStack creates an empty BoundedStack that holds at most capacity items.
+// It panics if the capacity is less than 1.
+//
+// Example usage:
+//
+//	undo := NewBoundedStack[Edit](100, OverflowDropOldest)
+//	undo.Push(edit) // forgets the oldest edit once 100 edits are stored
+//
+//	depth := NewBoundedStack[string](64, OverflowReject)
+//	if err := depth.PushE(call); err != nil {
+//	    return err // *OverflowError
+//	}
+func NewBoundedStack[T any](capacity int, policy OverflowPolicy) *BoundedStack[T] {
+	if capacity < 1 {
+		panic("l: capacity must be positive")
+	}
+	return &BoundedStack[T]{
+		policy: policy,
+		items:  make([]T, capacity),
+		popped: make(chan struct{}),
+	}
+}
+
+// Push adds an item to the top of the stack and applies the overflow policy if the stack is full.
+// With OverflowReject, it panics with an *OverflowError, which suits guards against too deep recursion.
+func (s *BoundedStack[T]) Push(item T) {
+	if err := s.PushE(item); err != nil {
+		panic(err)
+	}
+}
+
+// PushE adds an item to the top of the stack and applies the overflow policy if the stack is full.
+// With OverflowReject, it returns an *OverflowError and does not add the item.
+func (s *BoundedStack[T]) PushE(item T) error {
+	return s.PushContext(context.Background(), item)
+}
+
+// PushContext is like PushE, but with OverflowBlock it returns the context's error if the context is done before
+// an item is popped.
+func (s *BoundedStack[T]) PushContext(ctx context.Context, item T) error {
+	for {
+		s.mu.Lock()
+		if s.length < len(s.items) {
+			s.items[(s.bottom+s.length)%len(s.items)] = item
+			s.length++
+			s.mu.Unlock()
+			return nil
+		}
+
+		switch s.policy {
+		case OverflowDropOldest:
+			s.items[s.bottom] = item
+			s.bottom = (s.bottom + 1) % len(s.items)
+			s.mu.Unlock()
+			return nil
+		case OverflowBlock:
+			popped := s.popped
+			s.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-popped:
+			}
+		default:
+			s.mu.Unlock()
+			return &OverflowError{Capacity: len(s.items)}
+		}
+	}
+}
+
+// Pop removes and returns the top item of the stack. If the stack is empty, it returns nil.
+func (s *BoundedStack[T]) Pop() *T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.length == 0 {
+		return nil
+	}
+	s.length--
+	index := (s.bottom + s.length) % len(s.items)
+	item := s.items[index]
+	var zero T
+	s.items[index] = zero
+	s.wake()
+	return &item
+}
+
+// Peek returns a pointer to a copy of the top item of the stack. If the stack is empty, it returns nil.
+func (s *BoundedStack[T]) Peek() *T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.length == 0 {
+		return nil
+	}
+	item := s.items[(s.bottom+s.length-1)%len(s.items)]
+	return &item
+}
+
+// Length returns the number of items in the stack.
+func (s *BoundedStack[T]) Length() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.length
+}
+
+// Cap returns the maximum number of items in the stack.
+func (s *BoundedStack[T]) Cap() int {
+	return len(s.items)
+}
+
+// IsEmpty returns true if the stack has no items, false otherwise.
+func (s *BoundedStack[T]) IsEmpty() bool {
+	return s.Length() == 0
+}
+
+// IsFull returns true if the stack holds as many items as its capacity, false otherwise.
+func (s *BoundedStack[T]) IsFull() bool {
+	return s.Length() == len(s.items)
+}
+
+// Clear removes all items from the stack.
+func (s *BoundedStack[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.items)
+	s.bottom, s.length = 0, 0
+	s.wake()
+}
+
+// All returns a function that calls yield with every item of the stack from the top to the bottom,
+// until yield returns false. It iterates over a snapshot, so yield may use the stack.
+func (s *BoundedStack[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		s.mu.Lock()
+		items := make([]T, s.length)
+		for i := range items {
+			items[i] = s.items[(s.bottom+s.length-1-i)%len(s.items)]
+		}
+		s.mu.Unlock()
+
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// wake wakes up the pushers that wait for space. It must be called with the lock held.
+func (s *BoundedStack[T]) wake() {
+	close(s.popped)
+	s.popped = make(chan struct{})
+}
@@ -0,0 +1,144 @@
+package l
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBoundedStack_Policies(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   OverflowPolicy
+		wantErr  bool
+		expected []int
+	}{
+		{name: "reject keeps the old items", policy: OverflowReject, wantErr: true, expected: []int{3, 2, 1}},
+		{name: "drop oldest removes the bottom", policy: OverflowDropOldest, expected: []int{4, 3, 2}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewBoundedStack[int](3, tc.policy)
+			for i := 1; i <= 3; i++ {
+				if err := s.PushE(i); err != nil {
+					t.Fatalf("PushE(%d) = %v", i, err)
+				}
+			}
+			if !s.IsFull() || s.Cap() != 3 {
+				t.Errorf("IsFull() = false or Cap() = %d, want a full stack of 3", s.Cap())
+			}
+
+			err := s.PushE(4)
+			var overflow *OverflowError
+			if tc.wantErr != errors.As(err, &overflow) {
+				t.Fatalf("PushE() on a full stack = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr && overflow.Capacity != 3 {
+				t.Errorf("OverflowError.Capacity = %d, want 3", overflow.Capacity)
+			}
+			if got := ToList[int](s).Slice(); !slices.Equal(got, tc.expected) {
+				t.Errorf("All() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBoundedStack_PushPop(t *testing.T) {
+	s := NewBoundedStack[int](2, OverflowDropOldest)
+	for i := 1; i <= 5; i++ {
+		s.Push(i)
+	}
+	if got := s.Peek(); got == nil || *got != 5 {
+		t.Errorf("Peek() = %v, want 5", got)
+	}
+	if *s.Pop() != 5 || *s.Pop() != 4 || s.Pop() != nil || s.Peek() != nil {
+		t.Errorf("Pop() did not return 5, 4 and nil")
+	}
+
+	// The ring buffer wraps around.
+	s.Push(6)
+	s.Push(7)
+	s.Push(8)
+	if got := ToList[int](s).Slice(); !slices.Equal(got, []int{8, 7}) {
+		t.Errorf("All() = %v, want [8 7]", got)
+	}
+
+	s.Clear()
+	if !s.IsEmpty() || s.Length() != 0 {
+		t.Errorf("stack not empty after Clear")
+	}
+
+	rejecting := NewBoundedStack[int](1, OverflowReject)
+	rejecting.Push(1)
+	assertPanics(t, "Push() onto a full stack", func() { rejecting.Push(2) })
+	if err := (&OverflowError{Capacity: 1}).Error(); err != "l: stack is full at capacity 1" {
+		t.Errorf("Error() = %q", err)
+	}
+	assertPanics(t, "NewBoundedStack(0)", func() { NewBoundedStack[int](0, OverflowReject) })
+}
+
+func TestBoundedStack_Block(t *testing.T) {
+	s := NewBoundedStack[int](1, OverflowBlock)
+	s.Push(1)
+
+	pushed := make(chan error)
+	go func() {
+		pushed <- s.PushE(2)
+	}()
+
+	select {
+	case err := <-pushed:
+		t.Fatalf("PushE() on a full stack returned %v without waiting", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if got := *s.Pop(); got != 1 {
+		t.Errorf("Pop() = %d, want 1", got)
+	}
+	if err := <-pushed; err != nil {
+		t.Fatalf("PushE() = %v after Pop", err)
+	}
+	if got := *s.Peek(); got != 2 {
+		t.Errorf("Peek() = %d, want 2", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.PushContext(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PushContext() = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestBoundedStack_Concurrent(t *testing.T) {
+	s := NewBoundedStack[int](4, OverflowBlock)
+	const producers, items = 4, 250
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < items; i++ {
+				s.Push(i)
+			}
+		}()
+	}
+
+	popped := 0
+	for popped < producers*items {
+		if s.Pop() != nil {
+			popped++
+		} else {
+			runtime.Gosched()
+		}
+	}
+	wg.Wait()
+	if !s.IsEmpty() {
+		t.Errorf("Length() = %d, want 0", s.Length())
+	}
+}
@@ -0,0 +1,97 @@
+package l
+
+// GrowthPolicy computes the new capacity of a List whose items do not fit into the current capacity.
+// The result is raised to the needed capacity if it is smaller.
+type GrowthPolicy func(capacity, needed int) int
+
+// GrowByFactor returns a GrowthPolicy that multiplies the capacity by the factor, which must be greater than 1.
+// The capacity grows by at least one item, so small capacities also grow geometrically.
+// The built-in append grows large slices by a factor of about 1.25 and small ones by 2.
+func GrowByFactor(factor float64) GrowthPolicy {
+	if factor <= 1 {
+		panic("l: growth factor must be greater than 1")
+	}
+	return func(capacity, needed int) int {
+		return max(int(float64(capacity)*factor), capacity+1)
+	}
+}
+
+// GrowByChunk returns a GrowthPolicy that grows the capacity in multiples of the chunk size, which keeps the
+// unused capacity below one chunk.
+func GrowByChunk(size int) GrowthPolicy {
+	if size < 1 {
+		panic("l: growth chunk size must be positive")
+	}
+	return func(capacity, needed int) int {
+		return (needed + size - 1) / size * size
+	}
+}
+
+// NewListWithCapacity creates an empty List that can hold the given number of items without allocating.
+//
+// Example usage:
+//
+//	l := NewListWithCapacity[int](1024)
+//	for tick := range ticks {
+//	    l.Reset()
+//	    // add up to 1024 items without allocating
+//	}
+func NewListWithCapacity[T any](capacity int) List[T] {
+	return List[T]{items: make([]T, 0, capacity)}
+}
+
+// Cap returns the number of items the list can hold without allocating.
+func (l *List[T]) Cap() int {
+	return cap(l.items)
+}
+
+// Grow makes sure that n more items can be added to the list without allocating. It panics if n is negative.
+func (l *List[T]) Grow(n int) {
+	if n < 0 {
+		panic("l: cannot grow by a negative number of items")
+	}
+	if len(l.items)+n > cap(l.items) {
+		l.resize(len(l.items) + n)
+	}
+}
+
+// ShrinkToFit reduces the capacity of the list to its length, so the unused memory can be freed.
+func (l *List[T]) ShrinkToFit() {
+	if cap(l.items) > len(l.items) {
+		l.resize(len(l.items))
+	}
+}
+
+// Reset removes all items from the list but, unlike Clear, keeps the capacity for new items. The items are set
+// to their zero value, so they can be garbage collected.
+func (l *List[T]) Reset() {
+	l.modify("Reset")
+	clear(l.items)
+	l.items = l.items[:0]
+}
+
+// SetGrowthPolicy sets the policy that computes the new capacity when added items do not fit. A nil policy
+// restores the growth of the built-in append.
+//
+// Example usage:
+//
+//	l := NewList[int]()
+//	l.SetGrowthPolicy(GrowByChunk(256))
+//	l.Add(1) // l.Cap() is 256
+func (l *List[T]) SetGrowthPolicy(policy GrowthPolicy) {
+	l.growth = policy
+}
+
+// reserve makes room for n more items with the growth policy. Without a policy, append grows the items.
+func (l *List[T]) reserve(n int) {
+	needed := len(l.items) + n
+	if l.growth != nil && needed > cap(l.items) {
+		l.resize(max(l.growth(cap(l.items), needed), needed))
+	}
+}
+
+func (l *List[T]) resize(capacity int) {
+	items := make([]T, len(l.items), capacity)
+	copy(items, l.items)
+	l.items = items
+}
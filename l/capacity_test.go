@@ -0,0 +1,130 @@
+package l
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestList_Capacity(t *testing.T) {
+	list := NewListWithCapacity[int](8)
+	if list.Cap() != 8 || list.Length() != 0 {
+		t.Fatalf("Cap() = %d, Length() = %d, want 8 and 0", list.Cap(), list.Length())
+	}
+
+	list.Add(1, 2, 3)
+	backing := &list.Slice()[0]
+	list.Grow(5)
+	if list.Cap() != 8 || &list.Slice()[0] != backing {
+		t.Errorf("Grow() within the capacity reallocated, Cap() = %d", list.Cap())
+	}
+	list.Grow(10)
+	if list.Cap() < 13 {
+		t.Errorf("Cap() = %d after Grow(10), want at least 13", list.Cap())
+	}
+
+	list.ShrinkToFit()
+	if list.Cap() != 3 {
+		t.Errorf("Cap() = %d after ShrinkToFit, want 3", list.Cap())
+	}
+	if got := list.Slice(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Slice() = %v, want [1 2 3]", got)
+	}
+	assertPanics(t, "Grow(-1)", func() { list.Grow(-1) })
+}
+
+func TestList_Reset(t *testing.T) {
+	first, second := new(int), new(int)
+	list := NewListWithCapacity[*int](4)
+	list.Add(first, second)
+	backing := list.Slice()
+
+	list.Reset()
+	if !list.IsEmpty() || list.Cap() != 4 {
+		t.Errorf("Length() = %d, Cap() = %d after Reset, want 0 and 4", list.Length(), list.Cap())
+	}
+	if backing[0] != nil || backing[1] != nil {
+		t.Errorf("Reset did not zero the items")
+	}
+
+	list.Add(first)
+	if &list.Slice()[0] != &backing[0] {
+		t.Errorf("Add after Reset reallocated")
+	}
+
+	view := list.SubList(0, 1)
+	list.Reset()
+	assertPanics(t, "SubList after Reset", func() { view.Length() })
+}
+
+func TestList_GrowthPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   GrowthPolicy
+		adds     []int
+		expected []int
+	}{
+		{name: "chunks", policy: GrowByChunk(4), adds: []int{1, 3, 1, 4}, expected: []int{4, 4, 8, 12}},
+		{name: "factor", policy: GrowByFactor(2), adds: []int{1, 1, 1, 2}, expected: []int{1, 2, 4, 8}},
+		{name: "factor below the needed capacity", policy: GrowByFactor(1.5), adds: []int{5, 1}, expected: []int{5, 7}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			list := NewList[int]()
+			list.SetGrowthPolicy(tc.policy)
+			for i, n := range tc.adds {
+				list.Add(make([]int, n)...)
+				if list.Cap() != tc.expected[i] {
+					t.Errorf("Cap() = %d after adding %d items, want %d", list.Cap(), n, tc.expected[i])
+				}
+			}
+		})
+	}
+
+	list := NewList(1, 2)
+	list.SetGrowthPolicy(GrowByChunk(10))
+	list.Insert(0, 0)
+	if list.Cap() != 10 || !reflect.DeepEqual(list.Slice(), []int{0, 1, 2}) {
+		t.Errorf("Insert() with a policy: Cap() = %d, Slice() = %v", list.Cap(), list.Slice())
+	}
+
+	assertPanics(t, "GrowByFactor(1)", func() { GrowByFactor(1) })
+	assertPanics(t, "GrowByChunk(0)", func() { GrowByChunk(0) })
+}
+
+func TestGrowByFactor_Reallocations(t *testing.T) {
+	tests := []struct {
+		name   string
+		factor float64
+		max    int
+	}{
+		{name: "factor 1.5", factor: 1.5, max: 20},
+		{name: "factor 1.1", factor: 1.1, max: 80},
+		{name: "factor 2", factor: 2, max: 12},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := GrowByFactor(tc.factor)
+			for capacity := 0; capacity < 10; capacity++ {
+				if got := policy(capacity, capacity+1); got <= capacity {
+					t.Errorf("policy(%d) = %d, want a larger capacity", capacity, got)
+				}
+			}
+
+			list := NewList[int]()
+			list.SetGrowthPolicy(policy)
+			reallocations := 0
+			for i := 0; i < 1000; i++ {
+				capacity := list.Cap()
+				list.Add(i)
+				if list.Cap() != capacity {
+					reallocations++
+				}
+			}
+			if reallocations > tc.max {
+				t.Errorf("%d reallocations for 1000 items, want at most %d", reallocations, tc.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package l
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// FromChan receives items from the channel and collects them into a new List.
+// It returns when the channel is closed or the context is done, whichever happens first.
+//
+// Example usage:
+//
+//	ch := make(chan int, 3)
+//	ch <- 1
+//	ch <- 2
+//	close(ch)
+//	list := FromChan(context.Background(), ch) // list.Slice() is []int{1, 2}
+func FromChan[T any](ctx context.Context, ch <-chan T) List[T] {
+	list := NewList[T]()
+	receive(ctx, ch, func(item T) { list.Add(item) })
+	return list
+}
+
+// QueueFromChan receives items from the channel and pushes them into a new Queue in the order they arrive.
+// It returns when the channel is closed or the context is done, whichever happens first.
+func QueueFromChan[T any](ctx context.Context, ch <-chan T) Queue[T] {
+	queue := NewQueue[T]()
+	receive(ctx, ch, queue.Push)
+	return queue
+}
+
+// Chan returns a channel that receives the items of the list in order.
+// The channel is closed after the last item or when the context is done.
+// The items are copied when Chan is called, so later changes to the list are not sent.
+// A consumer that stops early must cancel the context, otherwise the sending goroutine is never released.
+func (l *List[T]) Chan(ctx context.Context) <-chan T {
+	out := make(chan T)
+	items := slices.Clone(l.items)
+	go func() {
+		defer close(out)
+		for _, item := range items {
+			select {
+			case out <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Drain returns a channel that receives the items of the queue in FIFO order. Every item is popped from the queue
+// only after it has been received, so the items that were not delivered stay in the queue when the context is done.
+// The channel is closed when the queue is empty or the context is done.
+// The queue must not be used by other goroutines until the channel is closed.
+func (q *Queue[T]) Drain(ctx context.Context) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for item := q.Peek(); item != nil; item = q.Peek() {
+			select {
+			case out <- *item:
+				q.Pop()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Drain returns a channel that receives the items of the stack in LIFO order. Every item is popped from the stack
+// only after it has been received, so the items that were not delivered stay on the stack when the context is done.
+// The channel is closed when the stack is empty or the context is done.
+// The stack must not be used by other goroutines until the channel is closed.
+func (s *Stack[T]) Drain(ctx context.Context) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for item := s.Peek(); item != nil; item = s.Peek() {
+			select {
+			case out <- *item:
+				s.Pop()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Pipe connects the input channel to the returned output channel through an unbounded Queue, so sending to the
+// input never blocks on a slow receiver (an "infinite channel"). Items are delivered in the order they were sent.
+// The output channel is closed after the input channel is closed and all buffered items have been delivered,
+// or as soon as the context is done.
+func Pipe[T any](ctx context.Context, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		buffer := NewQueue[T]()
+		for in != nil || buffer.Length() > 0 {
+			// Sending on a nil channel blocks forever, which disables the send case while the buffer is empty.
+			var send chan<- T
+			var next T
+			if item := buffer.Peek(); item != nil {
+				send = out
+				next = *item
+			}
+
+			select {
+			case item, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				buffer.Push(item)
+			case send <- next:
+				buffer.Pop()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// FanIn merges the input channels into a single output channel. The order of items from different inputs is not
+// defined. The output channel is closed after all input channels are closed or when the context is done.
+func FanIn[T any](ctx context.Context, inputs ...<-chan T) <-chan T {
+	out := make(chan T)
+	wg := sync.WaitGroup{}
+	wg.Add(len(inputs))
+	for _, in := range inputs {
+		go func(in <-chan T) {
+			defer wg.Done()
+			forward(ctx, in, out)
+		}(in)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// FanOut distributes the items of the input channel between n output channels. Every item is delivered to exactly
+// one output, so the outputs can be consumed by independent workers. The output channels are closed after the input
+// channel is closed or when the context is done. It panics if n is negative.
+func FanOut[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	if n < 0 {
+		panic("l: negative number of outputs")
+	}
+	outputs := make([]<-chan T, n)
+	for i := range outputs {
+		out := make(chan T)
+		outputs[i] = out
+		go func() {
+			defer close(out)
+			forward(ctx, in, out)
+		}()
+	}
+	return outputs
+}
+
+// receive calls f for every item received from the channel until it is closed or the context is done.
+func receive[T any](ctx context.Context, ch <-chan T, f func(T)) {
+	for {
+		select {
+		case item, ok := <-ch:
+			if !ok {
+				return
+			}
+			f(item)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// forward sends the items received from in to out until in is closed or the context is done.
+func forward[T any](ctx context.Context, in <-chan T, out chan<- T) {
+	receive(ctx, in, func(item T) {
+		select {
+		case out <- item:
+		case <-ctx.Done():
+		}
+	})
+}
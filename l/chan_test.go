@@ -0,0 +1,231 @@
+package l
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func sendAll[T any](items ...T) <-chan T {
+	ch := make(chan T, len(items))
+	for _, item := range items {
+		ch <- item
+	}
+	close(ch)
+	return ch
+}
+
+func collect[T any](ch <-chan T) []T {
+	got := []T{}
+	for item := range ch {
+		got = append(got, item)
+	}
+	return got
+}
+
+func TestFromChan(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+	}{
+		{
+			name:  "closed empty channel",
+			input: []int{},
+		},
+		{
+			name:  "multiple items",
+			input: []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			list := FromChan(context.Background(), sendAll(tc.input...))
+			if got := list.Slice(); !reflect.DeepEqual(got, tc.input) {
+				t.Errorf("FromChan() = %v, want %v", got, tc.input)
+			}
+
+			queue := QueueFromChan(context.Background(), sendAll(tc.input...))
+			got := []int{}
+			for item := queue.Pop(); item != nil; item = queue.Pop() {
+				got = append(got, *item)
+			}
+			if !reflect.DeepEqual(got, tc.input) {
+				t.Errorf("QueueFromChan() = %v, want %v", got, tc.input)
+			}
+		})
+	}
+}
+
+func TestFromChan_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	list := FromChan(ctx, make(chan int))
+	if !list.IsEmpty() {
+		t.Errorf("FromChan() on canceled context = %v, want empty list", list.Slice())
+	}
+}
+
+func TestList_Chan(t *testing.T) {
+	list := NewList("a", "b", "c")
+	if got := collect(list.Chan(context.Background())); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Chan() = %v, want [a b c]", got)
+	}
+}
+
+func TestList_Chan_Modification(t *testing.T) {
+	list := NewList(1, 2, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := list.Chan(ctx)
+	first := <-ch
+
+	// The channel sends a snapshot, so the list can be modified while it is open.
+	list.Add(4)
+	list.Remove(0)
+	*list.Get(0) = 20
+	if got := append([]int{first}, collect(ch)...); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Chan() = %v, want [1 2 3]", got)
+	}
+	if got := list.Slice(); !reflect.DeepEqual(got, []int{20, 3, 4}) {
+		t.Errorf("list = %v, want [20 3 4]", got)
+	}
+}
+
+func TestQueue_Drain(t *testing.T) {
+	q := NewQueue(1, 2, 3)
+	if got := collect(q.Drain(context.Background())); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Drain() = %v, want [1 2 3]", got)
+	}
+	if q.Length() != 0 {
+		t.Errorf("Length() after Drain = %d, want 0", q.Length())
+	}
+}
+
+func TestQueue_DrainCanceled(t *testing.T) {
+	q := NewQueue(1, 2, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch := q.Drain(ctx)
+	if got := <-ch; got != 1 {
+		t.Errorf("first item = %v, want 1", got)
+	}
+	cancel()
+	received := 1 + len(collect(ch))
+
+	// The items that were not received stay in the queue.
+	if q.Length() != 3-received {
+		t.Errorf("Length() after cancel = %d, want %d", q.Length(), 3-received)
+	}
+}
+
+func TestStack_Drain(t *testing.T) {
+	s := NewStack(1, 2, 3)
+	if got := collect(s.Drain(context.Background())); !reflect.DeepEqual(got, []int{3, 2, 1}) {
+		t.Errorf("Drain() = %v, want [3 2 1]", got)
+	}
+	if s.Length() != 0 {
+		t.Errorf("Length() after Drain = %d, want 0", s.Length())
+	}
+}
+
+func TestPipe(t *testing.T) {
+	in := make(chan int)
+	out := Pipe(context.Background(), in)
+
+	// Sending never blocks although nobody receives from the output yet.
+	for i := 0; i < 1000; i++ {
+		select {
+		case in <- i:
+		case <-time.After(time.Second):
+			t.Fatalf("send %d blocked", i)
+		}
+	}
+	close(in)
+
+	got := collect(out)
+	if len(got) != 1000 {
+		t.Fatalf("received %d items, want 1000", len(got))
+	}
+	for i, item := range got {
+		if item != i {
+			t.Fatalf("item %d = %d, want %d", i, item, i)
+		}
+	}
+}
+
+func TestPipe_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := Pipe(ctx, in)
+
+	in <- 1
+	cancel()
+	select {
+	case <-time.After(time.Second):
+		t.Fatalf("output not closed after cancel")
+	case _, ok := <-out:
+		for ok {
+			_, ok = <-out
+		}
+	}
+}
+
+func TestFanIn(t *testing.T) {
+	tests := []struct {
+		name   string
+		inputs [][]int
+		want   []int
+	}{
+		{
+			name:   "no inputs",
+			inputs: [][]int{},
+			want:   []int{},
+		},
+		{
+			name:   "multiple inputs",
+			inputs: [][]int{{1, 2}, {3}, {}, {4, 5, 6}},
+			want:   []int{1, 2, 3, 4, 5, 6},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			inputs := make([]<-chan int, len(tc.inputs))
+			for i, items := range tc.inputs {
+				inputs[i] = sendAll(items...)
+			}
+
+			got := collect(FanIn(context.Background(), inputs...))
+			sort.Ints(got)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("FanIn() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	input := []int{}
+	for i := 0; i < 100; i++ {
+		input = append(input, i)
+	}
+
+	outputs := FanOut(context.Background(), sendAll(input...), 3)
+	if len(outputs) != 3 {
+		t.Fatalf("FanOut() returned %d outputs, want 3", len(outputs))
+	}
+
+	got := collect(FanIn(context.Background(), outputs...))
+	sort.Ints(got)
+	if !reflect.DeepEqual(got, input) {
+		t.Errorf("FanOut() delivered %v, want %v", got, input)
+	}
+}
+
+func TestFanOut_Negative(t *testing.T) {
+	assertPanics(t, "FanOut() with n < 0", func() { FanOut(context.Background(), sendAll(1), -1) })
+}
@@ -0,0 +1,175 @@
+package l
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers. Time-based structures accept a Clock so that tests can replace
+// the system clock with a FakeClock and advance time deterministically.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer creates a Timer that fires once after the duration d.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single-shot timer created by a Clock.
+type Timer interface {
+	// C returns the channel on which the time is delivered when the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It returns false if the timer has already fired or been stopped.
+	Stop() bool
+}
+
+// SystemClock is a Clock backed by the time package.
+type SystemClock struct{}
+
+// Now returns time.Now().
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTimer returns a Timer backed by time.NewTimer.
+func (SystemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{timer: time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	timer *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t systemTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+// FakeClock is a Clock whose time only changes when Advance or Set is called.
+// Timers created by a FakeClock fire as soon as the clock reaches their deadline.
+// A FakeClock is safe for concurrent use.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []*fakeTimer
+	changed chan struct{}
+}
+
+// NewFakeClock creates a FakeClock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now, changed: make(chan struct{})}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// NewTimer creates a Timer that fires when the clock has been advanced by d.
+// A timer with a non-positive duration fires immediately.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := &fakeTimer{clock: c, deadline: c.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		timer.ch <- c.now
+		return timer
+	}
+
+	c.timers = append(c.timers, timer)
+	c.notify()
+	return timer
+}
+
+// Advance moves the clock forward by d and fires the timers that have reached their deadline.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(c.now.Add(d))
+}
+
+// Set moves the clock to the given time and fires the timers that have reached their deadline.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(now)
+}
+
+// Timers returns the number of timers that are waiting to fire.
+func (c *FakeClock) Timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
+
+// BlockUntil blocks until at least n timers are waiting to fire.
+// It lets a test wait until a goroutine is sleeping on the clock before advancing it.
+func (c *FakeClock) BlockUntil(n int) {
+	for {
+		c.mu.Lock()
+		if len(c.timers) >= n {
+			c.mu.Unlock()
+			return
+		}
+		changed := c.changed
+		c.mu.Unlock()
+		<-changed
+	}
+}
+
+func (c *FakeClock) set(now time.Time) {
+	c.now = now
+
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.deadline.After(now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.ch <- now
+	}
+	for i := len(pending); i < len(c.timers); i++ {
+		c.timers[i] = nil
+	}
+	c.timers = pending
+	c.notify()
+}
+
+// notify wakes up the goroutines waiting in BlockUntil.
+func (c *FakeClock) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	for i, timer := range t.clock.timers {
+		if timer == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			t.clock.notify()
+			return true
+		}
+	}
+	return false
+}
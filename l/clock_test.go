@@ -0,0 +1,83 @@
+package l
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock_Advance(t *testing.T) {
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		advance   time.Duration
+		fired     []bool
+	}{
+		{
+			name:      "no timers",
+			durations: []time.Duration{},
+			advance:   time.Second,
+			fired:     []bool{},
+		},
+		{
+			name:      "deadline reached",
+			durations: []time.Duration{time.Second, 2 * time.Second},
+			advance:   time.Second,
+			fired:     []bool{true, false},
+		},
+		{
+			name:      "immediate timer",
+			durations: []time.Duration{0, time.Minute},
+			advance:   0,
+			fired:     []bool{true, false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := NewFakeClock(delayEpoch)
+			timers := make([]Timer, len(tc.durations))
+			for i, d := range tc.durations {
+				timers[i] = clock.NewTimer(d)
+			}
+
+			clock.Advance(tc.advance)
+			if got, want := clock.Now(), delayEpoch.Add(tc.advance); !got.Equal(want) {
+				t.Errorf("Now() = %v, want %v", got, want)
+			}
+
+			for i, timer := range timers {
+				fired := false
+				select {
+				case <-timer.C():
+					fired = true
+				default:
+				}
+				if fired != tc.fired[i] {
+					t.Errorf("timer %d fired = %v, want %v", i, fired, tc.fired[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFakeClock_Stop(t *testing.T) {
+	clock := NewFakeClock(delayEpoch)
+	timer := clock.NewTimer(time.Second)
+
+	if clock.Timers() != 1 {
+		t.Fatalf("Timers() = %d, want 1", clock.Timers())
+	}
+	if !timer.Stop() {
+		t.Errorf("Stop() = false, want true")
+	}
+	if timer.Stop() {
+		t.Errorf("second Stop() = true, want false")
+	}
+
+	clock.Set(delayEpoch.Add(time.Hour))
+	select {
+	case <-timer.C():
+		t.Errorf("stopped timer fired")
+	default:
+	}
+}
@@ -0,0 +1,51 @@
+package l
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Codec converts items to and from their binary representation.
+// It is used by PersistentQueue to store items on disk.
+type Codec[T any] interface {
+	// Encode returns the binary representation of the item.
+	Encode(item T) ([]byte, error)
+	// Decode restores an item from the data produced by Encode.
+	Decode(data []byte) (T, error)
+}
+
+// JSONCodec is a Codec that stores items as JSON documents.
+type JSONCodec[T any] struct{}
+
+// Encode returns the JSON encoding of the item.
+func (JSONCodec[T]) Encode(item T) ([]byte, error) {
+	return json.Marshal(item)
+}
+
+// Decode parses the JSON encoded data into a new item.
+func (JSONCodec[T]) Decode(data []byte) (T, error) {
+	var item T
+	err := json.Unmarshal(data, &item)
+	return item, err
+}
+
+// GobCodec is a Codec that stores items using encoding/gob.
+// Every item is encoded as a self-contained gob stream, so records can be decoded independently of each other.
+type GobCodec[T any] struct{}
+
+// Encode returns the gob encoding of the item.
+func (GobCodec[T]) Encode(item T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(item); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode parses the gob encoded data into a new item.
+func (GobCodec[T]) Decode(data []byte) (T, error) {
+	var item T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&item)
+	return item, err
+}
@@ -0,0 +1,51 @@
+package l
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCodec_RoundTrip(t *testing.T) {
+	type record struct {
+		ID   int
+		Tags []string
+	}
+
+	tests := []struct {
+		name  string
+		codec Codec[record]
+		item  record
+	}{
+		{
+			name:  "json",
+			codec: JSONCodec[record]{},
+			item:  record{ID: 1, Tags: []string{"a", "b"}},
+		},
+		{
+			name:  "gob",
+			codec: GobCodec[record]{},
+			item:  record{ID: 2, Tags: []string{"c"}},
+		},
+		{
+			name:  "json zero value",
+			codec: JSONCodec[record]{},
+			item:  record{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := tc.codec.Encode(tc.item)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			got, err := tc.codec.Decode(data)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.item) {
+				t.Errorf("Decode(Encode()) = %v, want %v", got, tc.item)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package l
+
+import "reflect"
+
+// Collection is implemented by the structures of the package that hold a finite group of items.
+// All returns a function that calls yield with every item until yield returns false. Its shape is the same as
+// that of iter.Seq, so with Go 1.23 and later the items can also be ranged over:
+//
+//	for item := range list.All() { ... }
+//
+// With older versions, the function is called directly:
+//
+//	list.All()(func(item int) bool {
+//	    fmt.Println(item)
+//	    return true
+//	})
+//
+// A few structures do not implement it. SegmentTree, LazySegmentTree and FenwickTree have a length that is fixed
+// when they are created, so they cannot be cleared. The lock-free MPMCQueue and MPSCQueue cannot iterate over their
+// items while producers are pushing. The methods of PersistentQueue return the errors of its log, so their
+// signatures differ.
+type Collection[T any] interface {
+	Length() int
+	IsEmpty() bool
+	Clear()
+	All() func(yield func(T) bool)
+}
+
+// Sequence is a Collection with indexed access. The index of the first item is 0.
+type Sequence[T any] interface {
+	Collection[T]
+	// Get returns a pointer to the item at the index. It panics if the index is out of range.
+	Get(index int) *T
+}
+
+// Container is a Collection that adds and removes items at its own position, like a queue or a stack.
+type Container[T any] interface {
+	Collection[T]
+	// Push adds an item to the container.
+	Push(item T)
+	// Pop removes and returns the next item. If the container is empty, it returns nil.
+	Pop() *T
+	// Peek returns a pointer to the next item without removing it. If the container is empty, it returns nil.
+	Peek() *T
+}
+
+var (
+	_ Sequence[int]                  = (*List[int])(nil)
+	_ Sequence[int]                  = (*Rope[int])(nil)
+	_ Sequence[int]                  = (*GapBuffer[int])(nil)
+	_ Sequence[int]                  = (*SubList[int])(nil)
+	_ Container[int]                 = (*Queue[int])(nil)
+	_ Container[int]                 = (*Stack[int])(nil)
+	_ Container[int]                 = (*PriorityQueue[int])(nil)
+	_ Container[int]                 = (*MinMaxStack[int])(nil)
+	_ Container[int]                 = (*MonotonicQueue[int])(nil)
+	_ Container[int]                 = (*BoundedStack[int])(nil)
+	_ Collection[int]                = (*DelayQueue[int])(nil)
+	_ Collection[int]                = (*TreeSet[int])(nil)
+	_ Collection[int]                = (*DisjointSet[int])(nil)
+	_ Collection[int]                = (*BitSet)(nil)
+	_ Collection[uint32]             = (*RoaringBitmap)(nil)
+	_ Collection[Interval[int, int]] = (*IntervalTree[int, int])(nil)
+	_ Collection[Entry[int, int]]    = (*TreeMap[int, int])(nil)
+	_ Collection[Entry[int, int]]    = (*SkipList[int, int])(nil)
+	_ Collection[Entry[[]byte, int]] = (*RadixTree[byte, int])(nil)
+	_ Collection[Entry[string, int]] = (*Trie[int])(nil)
+	_ Collection[Entry[string, int]] = (*MultiMap[string, int])(nil)
+	_ Collection[Entry[string, int]] = (*BiMap[string, int])(nil)
+	_ Collection[Entry[string, int]] = (*FairQueue[string, int])(nil)
+)
+
+// Entry is a key and value pair of the maps and the keyed collections of the package, like TreeMap, Trie or
+// FairQueue.
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// ToList returns a List with the items of the collection in iteration order.
+//
+// Example usage:
+//
+//	s := NewStack(1, 2, 3)
+//	ToList[int](&s) // [3, 2, 1]
+func ToList[T any](c Collection[T]) *List[T] {
+	list := NewList[T]()
+	c.All()(func(item T) bool {
+		list.Add(item)
+		return true
+	})
+	return &list
+}
+
+// CopyInto pushes the items of the collection into the container in iteration order.
+// It returns the number of pushed items.
+func CopyInto[T any](dst Container[T], src Collection[T]) int {
+	count := 0
+	src.All()(func(item T) bool {
+		dst.Push(item)
+		count++
+		return true
+	})
+	return count
+}
+
+// EqualCollections returns true if both collections have the same length and yield equal items in the same
+// order. The items are compared with reflect.DeepEqual.
+func EqualCollections[T any](a, b Collection[T]) bool {
+	if a.Length() != b.Length() {
+		return false
+	}
+
+	items := ToList(a).items
+	i, equal := 0, true
+	b.All()(func(item T) bool {
+		equal = i < len(items) && reflect.DeepEqual(items[i], item)
+		i++
+		return equal
+	})
+	return equal && i == len(items)
+}
@@ -0,0 +1,180 @@
+package l
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCollection_All(t *testing.T) {
+	list := NewList(1, 2, 3)
+	queue := NewQueue(1, 2, 3)
+	stack := NewStack(1, 2, 3)
+	rope := NewRope(1, 2, 3)
+	buffer := NewGapBuffer(1, 2, 3)
+	set := NewTreeSet(3, 1, 2)
+	disjoint := NewDisjointSet(1, 2, 3)
+	bits := NewBitSet(3, 1, 2)
+
+	tests := []struct {
+		name       string
+		collection Collection[int]
+		want       []int
+	}{
+		{name: "list", collection: &list, want: []int{1, 2, 3}},
+		{name: "queue from first to last", collection: &queue, want: []int{1, 2, 3}},
+		{name: "stack from top to bottom", collection: &stack, want: []int{3, 2, 1}},
+		{name: "rope", collection: &rope, want: []int{1, 2, 3}},
+		{name: "gap buffer", collection: &buffer, want: []int{1, 2, 3}},
+		{name: "tree set in order", collection: &set, want: []int{1, 2, 3}},
+		{name: "disjoint set in insertion order", collection: &disjoint, want: []int{1, 2, 3}},
+		{name: "bit set in order", collection: &bits, want: []int{1, 2, 3}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ToList(tc.collection).Slice(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ToList() = %v, want %v", got, tc.want)
+			}
+
+			// The iteration stops as soon as yield returns false.
+			visited := 0
+			tc.collection.All()(func(int) bool {
+				visited++
+				return visited < 2
+			})
+			if visited != 2 {
+				t.Errorf("All() visited %d items after stop, want 2", visited)
+			}
+
+			if tc.collection.Length() != 3 || tc.collection.IsEmpty() {
+				t.Errorf("Length() = %d, want 3", tc.collection.Length())
+			}
+			tc.collection.Clear()
+			if tc.collection.Length() != 0 || !tc.collection.IsEmpty() {
+				t.Errorf("collection not empty after Clear")
+			}
+		})
+	}
+}
+
+func TestCollection_RoaringBitmap(t *testing.T) {
+	r := NewRoaringBitmap(1<<20, 3, 1)
+	if got := ToList[uint32](&r).Slice(); !reflect.DeepEqual(got, []uint32{1, 3, 1 << 20}) {
+		t.Errorf("ToList() = %v, want [1 3 %d]", got, 1<<20)
+	}
+
+	visited := 0
+	r.All()(func(uint32) bool {
+		visited++
+		return false
+	})
+	if visited != 1 {
+		t.Errorf("All() visited %d values after stop, want 1", visited)
+	}
+
+	r.Clear()
+	if r.Length() != 0 || !r.IsEmpty() {
+		t.Errorf("bitmap not empty after Clear")
+	}
+}
+
+func TestCollection_Maps(t *testing.T) {
+	treeMap := NewTreeMap[string, int]()
+	treeMap.Put("b", 2)
+	treeMap.Put("a", 1)
+	trie := NewTrie[int]()
+	trie.Insert("b", 2)
+	trie.Insert("a", 1)
+	multiMap := NewMultiMap[string, int]()
+	multiMap.Put("a", 1)
+	multiMap.Put("b", 2)
+	skipList := NewSkipList[string, int]()
+	skipList.Put("b", 2)
+	skipList.Put("a", 1)
+
+	want := []Entry[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}}
+	for name, collection := range map[string]Collection[Entry[string, int]]{
+		"tree map":  &treeMap,
+		"trie":      &trie,
+		"multi map": &multiMap,
+		"skip list": skipList,
+	} {
+		if got := ToList(collection).Slice(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: ToList() = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestCopyInto(t *testing.T) {
+	source := NewList(1, 2, 3)
+
+	tests := []struct {
+		name      string
+		container Container[int]
+		want      []int
+	}{
+		{name: "queue keeps the order", container: &Queue[int]{}, want: []int{1, 2, 3}},
+		{name: "stack reverses the order", container: &Stack[int]{}, want: []int{3, 2, 1}},
+		{name: "priority queue sorts", container: &PriorityQueue[int]{less: func(a, b int) bool { return a > b }}, want: []int{3, 2, 1}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if count := CopyInto[int](tc.container, &source); count != 3 {
+				t.Errorf("CopyInto() = %d, want 3", count)
+			}
+			got := []int{}
+			for item := tc.container.Pop(); item != nil; item = tc.container.Pop() {
+				got = append(got, *item)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("popped %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEqualCollections(t *testing.T) {
+	list := NewList(1, 2, 3)
+	queue := NewQueue(1, 2, 3)
+	stack := NewStack(1, 2, 3)
+	shorter := NewList(1, 2)
+	rope := NewRope(1, 2, 4)
+
+	tests := []struct {
+		name string
+		a, b Collection[int]
+		want bool
+	}{
+		{name: "list and queue", a: &list, b: &queue, want: true},
+		{name: "list and stack", a: &list, b: &stack, want: false},
+		{name: "different length", a: &list, b: &shorter, want: false},
+		{name: "different item", a: &list, b: &rope, want: false},
+		{name: "same collection", a: &stack, b: &stack, want: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := EqualCollections(tc.a, tc.b); got != tc.want {
+				t.Errorf("EqualCollections() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCollection_DelayQueue(t *testing.T) {
+	clock := NewFakeClock(delayEpoch)
+	q := NewDelayQueueWithClock[string](clock)
+	q.PushAfter("later", time.Hour)
+	q.PushAfter("now", 0)
+
+	items := ToList[string](q)
+	if items.Length() != 2 || !items.Contains("later") || !items.Contains("now") {
+		t.Errorf("ToList() = %v, want both items", items.Slice())
+	}
+	q.Clear()
+	if !q.IsEmpty() || q.Poll() != nil {
+		t.Errorf("queue not empty after Clear")
+	}
+}
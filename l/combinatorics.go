@@ -0,0 +1,266 @@
+package l
+
+// The generators of this file read the items when they are called and yield every result as a new List, so the
+// results can be kept or modified. Only the current result is kept in memory. The items are identified by their
+// index, so equal items in the input produce equal results more than once. Like List.All, the generators fail fast:
+// adding or removing items of an input list from yield panics with a ConcurrentModificationError.
+
+// Permutations returns a function that calls yield with every ordering of the items of the list, until yield
+// returns false. The orderings are generated in lexicographic order of the item indexes, starting with the list
+// itself. A list of n items has n! permutations.
+//
+// Example usage:
+//
+//	list := NewList(1, 2, 3)
+//	Permutations(&list)(func(p List[int]) bool {
+//	    fmt.Println(p.Slice()) // [1 2 3], [1 3 2], [2 1 3], [2 3 1], [3 1 2], [3 2 1]
+//	    return true
+//	})
+func Permutations[T any](list *List[T]) func(yield func(List[T]) bool) {
+	return func(yield func(List[T]) bool) {
+		items := list.Slice()
+		defer list.iterate()()
+		indexes := sequence(len(items))
+		for {
+			if !yield(pick(items, indexes)) {
+				return
+			}
+
+			// Find the longest non-increasing suffix, swap the item before it with the next larger item of the suffix
+			// and reverse the suffix.
+			i := len(indexes) - 2
+			for i >= 0 && indexes[i] >= indexes[i+1] {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			j := len(indexes) - 1
+			for indexes[j] <= indexes[i] {
+				j--
+			}
+			indexes[i], indexes[j] = indexes[j], indexes[i]
+			for a, b := i+1, len(indexes)-1; a < b; a, b = a+1, b-1 {
+				indexes[a], indexes[b] = indexes[b], indexes[a]
+			}
+		}
+	}
+}
+
+// Combinations returns a function that calls yield with every selection of k items of the list, until yield
+// returns false. The items of a selection keep their order in the list, and the selections are generated in
+// lexicographic order of the item indexes. If k is larger than the length, there are no selections.
+// It panics if k is negative.
+//
+// Example usage:
+//
+//	list := NewList("a", "b", "c")
+//	Combinations(&list, 2) // [a b], [a c], [b c]
+func Combinations[T any](list *List[T], k int) func(yield func(List[T]) bool) {
+	checkSelectionSize(k)
+	return func(yield func(List[T]) bool) {
+		items := list.Slice()
+		defer list.iterate()()
+		n := len(items)
+		if k > n {
+			return
+		}
+		indexes := sequence(k)
+		for {
+			if !yield(pick(items, indexes)) {
+				return
+			}
+
+			// Advance the last index that has not reached its final position and reset the indexes after it.
+			i := k - 1
+			for i >= 0 && indexes[i] == i+n-k {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indexes[i]++
+			for j := i + 1; j < k; j++ {
+				indexes[j] = indexes[j-1] + 1
+			}
+		}
+	}
+}
+
+// CombinationsWithReplacement returns a function that calls yield with every selection of k items of the list
+// where an item can be selected more than once, until yield returns false. The selections are generated in
+// lexicographic order of the item indexes. It panics if k is negative.
+//
+// Example usage:
+//
+//	list := NewList("a", "b")
+//	CombinationsWithReplacement(&list, 2) // [a a], [a b], [b b]
+func CombinationsWithReplacement[T any](list *List[T], k int) func(yield func(List[T]) bool) {
+	checkSelectionSize(k)
+	return func(yield func(List[T]) bool) {
+		items := list.Slice()
+		defer list.iterate()()
+		n := len(items)
+		if n == 0 && k > 0 {
+			return
+		}
+		indexes := make([]int, k)
+		for {
+			if !yield(pick(items, indexes)) {
+				return
+			}
+
+			i := k - 1
+			for i >= 0 && indexes[i] == n-1 {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indexes[i]++
+			for j := i + 1; j < k; j++ {
+				indexes[j] = indexes[i]
+			}
+		}
+	}
+}
+
+// CartesianProduct returns a function that calls yield with every combination of one item of each list, in the
+// order of the lists, until yield returns false. The last list varies the fastest. If one of the lists is empty,
+// there are no combinations, and without lists there is a single empty combination.
+//
+// Example usage:
+//
+//	sizes := NewList("S", "M")
+//	colors := NewList("red", "blue")
+//	CartesianProduct(&sizes, &colors) // [S red], [S blue], [M red], [M blue]
+func CartesianProduct[T any](lists ...*List[T]) func(yield func(List[T]) bool) {
+	return func(yield func(List[T]) bool) {
+		items := make([][]T, len(lists))
+		for i, list := range lists {
+			items[i] = list.Slice()
+			defer list.iterate()()
+		}
+		for _, list := range items {
+			if len(list) == 0 {
+				return
+			}
+		}
+		indexes := make([]int, len(items))
+		for {
+			product := NewList[T]()
+			for i, index := range indexes {
+				product.Add(items[i][index])
+			}
+			if !yield(product) {
+				return
+			}
+
+			i := len(indexes) - 1
+			for i >= 0 && indexes[i] == len(items[i])-1 {
+				indexes[i] = 0
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indexes[i]++
+		}
+	}
+}
+
+// PowerSet returns a function that calls yield with every subset of the items of the list, until yield returns
+// false. The subsets are generated by size, starting with the empty subset, and subsets of the same size in the
+// order of Combinations. A list of n items has 2^n subsets.
+//
+// Example usage:
+//
+//	list := NewList(1, 2, 3)
+//	PowerSet(&list) // [], [1], [2], [3], [1 2], [1 3], [2 3], [1 2 3]
+func PowerSet[T any](list *List[T]) func(yield func(List[T]) bool) {
+	return func(yield func(List[T]) bool) {
+		for k := 0; k <= list.Length(); k++ {
+			stopped := false
+			Combinations(list, k)(func(subset List[T]) bool {
+				stopped = !yield(subset)
+				return !stopped
+			})
+			if stopped {
+				return
+			}
+		}
+	}
+}
+
+// Partitions returns a function that calls yield with every partition of the items of the list into non-empty
+// groups, until yield returns false. The items of a group keep their order in the list, and the groups are ordered
+// by their first item. The partitions are generated in lexicographic order of their restricted growth strings, so
+// the first partition is a single group and the last one has a group per item. A list of n items has Bell(n)
+// partitions.
+//
+// Example usage:
+//
+//	list := NewList(1, 2, 3)
+//	Partitions(&list) // [[1 2 3]], [[1 2] [3]], [[1 3] [2]], [[1] [2 3]], [[1] [2] [3]]
+func Partitions[T any](list *List[T]) func(yield func(List[List[T]]) bool) {
+	return func(yield func(List[List[T]]) bool) {
+		items := list.Slice()
+		defer list.iterate()()
+		// groups[i] is the group of items[i]. Every item is at most in the group after the largest group before it.
+		groups := make([]int, len(items))
+		for {
+			partition := NewList[List[T]]()
+			for i, group := range groups {
+				if group == partition.Length() {
+					partition.Add(NewList[T]())
+				}
+				partition.items[group].Add(items[i])
+			}
+			if !yield(partition) {
+				return
+			}
+
+			i := len(groups) - 1
+			for i > 0 && groups[i] > largest(groups[:i]) {
+				i--
+			}
+			if i <= 0 {
+				return
+			}
+			groups[i]++
+			clear(groups[i+1:])
+		}
+	}
+}
+
+func checkSelectionSize(k int) {
+	if k < 0 {
+		panic("l: negative selection size")
+	}
+}
+
+// sequence returns the indexes 0, 1, ..., n-1.
+func sequence(n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return indexes
+}
+
+// pick returns a List with the items at the indexes.
+func pick[T any](items []T, indexes []int) List[T] {
+	picked := make([]T, len(indexes))
+	for i, index := range indexes {
+		picked[i] = items[index]
+	}
+	return NewList(picked...)
+}
+
+func largest(values []int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		result = max(result, value)
+	}
+	return result
+}
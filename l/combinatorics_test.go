@@ -0,0 +1,150 @@
+package l
+
+import (
+	"reflect"
+	"testing"
+)
+
+// generated returns the slices of the first limit results of the generator, or of all results if limit is 0.
+func generated[T any](generator func(yield func(List[T]) bool), limit int) [][]T {
+	results := [][]T{}
+	generator(func(result List[T]) bool {
+		results = append(results, append([]T{}, result.Slice()...))
+		return limit == 0 || len(results) < limit
+	})
+	return results
+}
+
+func TestCombinatorics(t *testing.T) {
+	empty := NewList[int]()
+	one := NewList(1)
+	three := NewList(1, 2, 3)
+	letters := NewList("a", "b")
+
+	tests := []struct {
+		name     string
+		got      [][]int
+		expected [][]int
+	}{
+		{
+			name:     "permutations",
+			got:      generated(Permutations(&three), 0),
+			expected: [][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}},
+		},
+		{name: "permutations of empty list", got: generated(Permutations(&empty), 0), expected: [][]int{{}}},
+		{name: "permutations stop", got: generated(Permutations(&three), 2), expected: [][]int{{1, 2, 3}, {1, 3, 2}}},
+		{
+			name:     "combinations",
+			got:      generated(Combinations(&three, 2), 0),
+			expected: [][]int{{1, 2}, {1, 3}, {2, 3}},
+		},
+		{name: "combinations of all items", got: generated(Combinations(&three, 3), 0), expected: [][]int{{1, 2, 3}}},
+		{name: "combinations of no items", got: generated(Combinations(&three, 0), 0), expected: [][]int{{}}},
+		{name: "combinations of too many items", got: generated(Combinations(&three, 4), 0), expected: [][]int{}},
+		{
+			name:     "combinations with replacement",
+			got:      generated(CombinationsWithReplacement(&three, 2), 0),
+			expected: [][]int{{1, 1}, {1, 2}, {1, 3}, {2, 2}, {2, 3}, {3, 3}},
+		},
+		{
+			name:     "combinations with replacement of more items than the list",
+			got:      generated(CombinationsWithReplacement(&one, 3), 0),
+			expected: [][]int{{1, 1, 1}},
+		},
+		{name: "combinations with replacement of empty list", got: generated(CombinationsWithReplacement(&empty, 2), 0), expected: [][]int{}},
+		{
+			name:     "cartesian product",
+			got:      generated(CartesianProduct(&one, &three, &one), 0),
+			expected: [][]int{{1, 1, 1}, {1, 2, 1}, {1, 3, 1}},
+		},
+		{name: "cartesian product with an empty list", got: generated(CartesianProduct(&three, &empty), 0), expected: [][]int{}},
+		{name: "cartesian product of no lists", got: generated(CartesianProduct[int](), 0), expected: [][]int{{}}},
+		{
+			name:     "power set",
+			got:      generated(PowerSet(&three), 0),
+			expected: [][]int{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}},
+		},
+		{name: "power set stops", got: generated(PowerSet(&three), 3), expected: [][]int{{}, {1}, {2}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.got, tc.expected) {
+				t.Errorf("got %v, want %v", tc.got, tc.expected)
+			}
+		})
+	}
+
+	if got := generated(CartesianProduct(&letters, &letters), 0); !reflect.DeepEqual(got, [][]string{{"a", "a"}, {"a", "b"}, {"b", "a"}, {"b", "b"}}) {
+		t.Errorf("CartesianProduct() = %v", got)
+	}
+	assertPanics(t, "Combinations(-1)", func() { Combinations(&three, -1) })
+}
+
+func TestCombinatorics_Counts(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5, 6)
+
+	counts := map[string]struct {
+		generator func(yield func(List[int]) bool)
+		expected  int
+	}{
+		"6!":              {generator: Permutations(&list), expected: 720},
+		"6 choose 3":      {generator: Combinations(&list, 3), expected: 20},
+		"6 multichoose 3": {generator: CombinationsWithReplacement(&list, 3), expected: 56},
+		"2^6":             {generator: PowerSet(&list), expected: 64},
+		"6 * 6 * 6":       {generator: CartesianProduct(&list, &list, &list), expected: 216},
+		"6 choose 6":      {generator: Combinations(&list, 6), expected: 1},
+	}
+
+	for name, tc := range counts {
+		if got := len(generated(tc.generator, 0)); got != tc.expected {
+			t.Errorf("%s: got %d results, want %d", name, got, tc.expected)
+		}
+	}
+}
+
+func TestPartitions(t *testing.T) {
+	list := NewList(1, 2, 3)
+	partitions := [][][]int{}
+	Partitions(&list)(func(partition List[List[int]]) bool {
+		groups := [][]int{}
+		partition.ForEach(func(_ int, group List[int]) {
+			groups = append(groups, group.Slice())
+		})
+		partitions = append(partitions, groups)
+		return true
+	})
+
+	expected := [][][]int{
+		{{1, 2, 3}},
+		{{1, 2}, {3}},
+		{{1, 3}, {2}},
+		{{1}, {2, 3}},
+		{{1}, {2}, {3}},
+	}
+	if !reflect.DeepEqual(partitions, expected) {
+		t.Errorf("Partitions() = %v, want %v", partitions, expected)
+	}
+
+	// The number of partitions are the Bell numbers.
+	for n, bell := range []int{1, 1, 2, 5, 15, 52, 203} {
+		items := NewList(sequence(n)...)
+		count := 0
+		Partitions(&items)(func(List[List[int]]) bool {
+			count++
+			return true
+		})
+		if count != bell {
+			t.Errorf("Partitions() of %d items = %d, want %d", n, count, bell)
+		}
+	}
+
+	stopped := 0
+	Partitions(&list)(func(List[List[int]]) bool {
+		stopped++
+		return false
+	})
+	if stopped != 1 {
+		t.Errorf("Partitions() continued after yield returned false")
+	}
+}
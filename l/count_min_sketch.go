@@ -0,0 +1,140 @@
+package l
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+const countMinSketchTag byte = 'M'
+
+// CountMinSketch estimates the frequency of items in a stream using a fixed amount of memory.
+// Estimates are never lower than the true count and, with probability 1-delta, exceed it by at most
+// epsilon times the total count of all items.
+type CountMinSketch[T any] struct {
+	width  uint64
+	depth  int
+	counts []uint64
+	total  uint64
+	hasher Hasher[T]
+}
+
+// NewCountMinSketch creates a CountMinSketch with the given error bound epsilon and failure probability delta,
+// using DefaultHasher. Values outside (0, 1) are replaced by 0.001 for epsilon and 0.01 for delta.
+func NewCountMinSketch[T any](epsilon, delta float64) *CountMinSketch[T] {
+	return NewCountMinSketchWithHasher(epsilon, delta, DefaultHasher[T]())
+}
+
+// NewCountMinSketchWithHasher creates a CountMinSketch like NewCountMinSketch that hashes the items
+// with the given hasher.
+func NewCountMinSketchWithHasher[T any](epsilon, delta float64, hasher Hasher[T]) *CountMinSketch[T] {
+	if epsilon <= 0 || epsilon >= 1 {
+		epsilon = 0.001
+	}
+	if delta <= 0 || delta >= 1 {
+		delta = 0.01
+	}
+
+	width := uint64(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	return &CountMinSketch[T]{
+		width:  width,
+		depth:  depth,
+		counts: make([]uint64, width*uint64(depth)),
+		hasher: hasher,
+	}
+}
+
+// Add increases the count of the item by the given amount.
+func (s *CountMinSketch[T]) Add(item T, count uint64) {
+	row := uint64(0)
+	s.indexes(item, func(index uint64) {
+		s.counts[row*s.width+index] += count
+		row++
+	})
+	s.total += count
+}
+
+// Estimate returns the estimated count of the item.
+func (s *CountMinSketch[T]) Estimate(item T) uint64 {
+	estimate := uint64(math.MaxUint64)
+	row := uint64(0)
+	s.indexes(item, func(index uint64) {
+		estimate = min(estimate, s.counts[row*s.width+index])
+		row++
+	})
+	return estimate
+}
+
+// Total returns the sum of all counts added to the sketch.
+func (s *CountMinSketch[T]) Total() uint64 {
+	return s.total
+}
+
+// Width returns the number of counters in every row of the sketch.
+func (s *CountMinSketch[T]) Width() int {
+	return int(s.width)
+}
+
+// Depth returns the number of rows of the sketch.
+func (s *CountMinSketch[T]) Depth() int {
+	return s.depth
+}
+
+// Clear resets all counts to zero.
+func (s *CountMinSketch[T]) Clear() {
+	clear(s.counts)
+	s.total = 0
+}
+
+// Merge adds the counts of other to the sketch. It returns ErrIncompatible if the dimensions differ.
+func (s *CountMinSketch[T]) Merge(other *CountMinSketch[T]) error {
+	if s.width != other.width || s.depth != other.depth {
+		return ErrIncompatible
+	}
+	for i, count := range other.counts {
+		s.counts[i] += count
+	}
+	s.total += other.total
+	return nil
+}
+
+// MarshalBinary encodes the sketch into a binary form. The hasher is not encoded.
+func (s *CountMinSketch[T]) MarshalBinary() ([]byte, error) {
+	data := marshalHeader(countMinSketchTag, s.width, uint64(s.depth), s.total)
+	for _, count := range s.counts {
+		data = binary.BigEndian.AppendUint64(data, count)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary restores a sketch encoded by MarshalBinary. The hasher of the sketch is kept,
+// or DefaultHasher is used if the sketch has none.
+func (s *CountMinSketch[T]) UnmarshalBinary(data []byte) error {
+	params, data, err := unmarshalHeader(data, countMinSketchTag, 3)
+	if err != nil {
+		return err
+	}
+	width, depth, total := params[0], params[1], params[2]
+	// Bound both factors by the payload before multiplying them, so the product cannot overflow.
+	counts := uint64(len(data)) / 8
+	if width == 0 || depth == 0 || width > counts || depth > counts/width || uint64(len(data)) != width*depth*8 {
+		return ErrInvalidData
+	}
+
+	s.counts = make([]uint64, width*depth)
+	for i := range s.counts {
+		s.counts[i] = binary.BigEndian.Uint64(data[8*i:])
+	}
+	s.width = width
+	s.depth = int(depth)
+	s.total = total
+	return nil
+}
+
+// indexes calls f with the column of the item in every row.
+func (s *CountMinSketch[T]) indexes(item T, f func(index uint64)) {
+	if s.hasher == nil {
+		s.hasher = DefaultHasher[T]()
+	}
+	hashIndexes(s.hasher(item), s.depth, s.width, f)
+}
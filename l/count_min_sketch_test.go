@@ -0,0 +1,90 @@
+package l
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCountMinSketch_Estimate(t *testing.T) {
+	s := NewCountMinSketch[string](0.001, 0.01)
+	counts := map[string]uint64{"a": 1000, "b": 100, "c": 10, "d": 1}
+	total := uint64(0)
+	for item, count := range counts {
+		s.Add(item, count)
+		total += count
+	}
+	// Noise from many rare items.
+	for i := 0; i < 1000; i++ {
+		s.Add(string(rune('A'+i%26))+string(rune(i)), 1)
+		total++
+	}
+
+	if s.Total() != total {
+		t.Errorf("Total() = %d, want %d", s.Total(), total)
+	}
+	for item, count := range counts {
+		got := s.Estimate(item)
+		if got < count || float64(got-count) > 0.001*float64(total) {
+			t.Errorf("Estimate(%q) = %d, want between %d and %d", item, got, count, count+total/1000)
+		}
+	}
+	if got := s.Estimate("missing"); float64(got) > 0.001*float64(total) {
+		t.Errorf("Estimate(missing) = %d, want at most %d", got, total/1000)
+	}
+}
+
+func TestCountMinSketch_MergeMarshal(t *testing.T) {
+	a := NewCountMinSketch[int](0.01, 0.01)
+	b := NewCountMinSketch[int](0.01, 0.01)
+	a.Add(1, 5)
+	b.Add(1, 3)
+	b.Add(2, 7)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if err := a.Merge(NewCountMinSketch[int](0.1, 0.01)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Merge() of different width error = %v, want %v", err, ErrIncompatible)
+	}
+
+	data, _ := a.MarshalBinary()
+	var restored CountMinSketch[int]
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if restored.Estimate(1) != 8 || restored.Estimate(2) != 7 || restored.Total() != 15 {
+		t.Errorf("restored estimates = %d, %d, total %d, want 8, 7, total 15", restored.Estimate(1), restored.Estimate(2), restored.Total())
+	}
+	if restored.Width() != a.Width() || restored.Depth() != a.Depth() {
+		t.Errorf("restored dimensions = %dx%d, want %dx%d", restored.Width(), restored.Depth(), a.Width(), a.Depth())
+	}
+
+	restored.Clear()
+	if restored.Estimate(1) != 0 || restored.Total() != 0 {
+		t.Errorf("sketch not empty after Clear")
+	}
+}
+
+func TestCountMinSketch_UnmarshalBinary_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, depth  uint64
+		payloadCounts int
+	}{
+		{name: "zero width", width: 0, depth: 1, payloadCounts: 0},
+		{name: "short payload", width: 4, depth: 2, payloadCounts: 7},
+		// 2^61 * 2^3 * 8 overflows to 0 and would match an empty payload.
+		{name: "overflowing dimensions", width: 1 << 61, depth: 1 << 3},
+		{name: "overflowing dimensions with payload", width: 1<<63 + 1, depth: 2, payloadCounts: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data := append(marshalHeader(countMinSketchTag, tc.width, tc.depth, 0), make([]byte, 8*tc.payloadCounts)...)
+			var s CountMinSketch[int]
+			if err := s.UnmarshalBinary(data); !errors.Is(err, ErrInvalidData) {
+				t.Errorf("UnmarshalBinary() error = %v, want %v", err, ErrInvalidData)
+			}
+		})
+	}
+}
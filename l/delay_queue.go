@@ -0,0 +1,187 @@
+package l
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DelayQueue is a queue in which every item becomes available at its own ready time.
+// Items are ordered by their ready time; items with the same ready time are returned in the order they were pushed.
+// The items are kept in a PriorityQueue, so Push and Take run in O(log n).
+// A DelayQueue is safe for concurrent use.
+type DelayQueue[T any] struct {
+	mu      sync.Mutex
+	clock   Clock
+	items   PriorityQueue[delayItem[T]]
+	seq     uint64
+	changed chan struct{}
+}
+
+type delayItem[T any] struct {
+	item    T
+	readyAt time.Time
+	seq     uint64
+}
+
+// NewDelayQueue creates an empty DelayQueue that uses the system clock.
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return NewDelayQueueWithClock[T](SystemClock{})
+}
+
+// NewDelayQueueWithClock creates an empty DelayQueue that reads the time from the given clock.
+//
+// Example usage:
+//
+//	clock := NewFakeClock(time.Now())
+//	q := NewDelayQueueWithClock[string](clock)
+//	q.PushAfter("retry", time.Minute)
+//	q.Poll() // nil, the item is not ready yet
+//	clock.Advance(time.Minute)
+//	q.Poll() // "retry"
+func NewDelayQueueWithClock[T any](clock Clock) *DelayQueue[T] {
+	return &DelayQueue[T]{
+		clock:   clock,
+		items:   NewPriorityQueue(delayItemLess[T]),
+		changed: make(chan struct{}),
+	}
+}
+
+// Push adds an item that becomes available at readyAt.
+func (q *DelayQueue[T]) Push(item T, readyAt time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.seq++
+	q.items.Push(delayItem[T]{item: item, readyAt: readyAt, seq: q.seq})
+	if q.items.Peek().seq == q.seq {
+		// The new item is the earliest one, the waiting consumers have to recompute their deadline.
+		close(q.changed)
+		q.changed = make(chan struct{})
+	}
+}
+
+// PushAfter adds an item that becomes available after the given delay.
+func (q *DelayQueue[T]) PushAfter(item T, delay time.Duration) {
+	q.Push(item, q.clock.Now().Add(delay))
+}
+
+// Poll removes and returns the earliest item if its ready time has arrived. Otherwise, it returns nil.
+func (q *DelayQueue[T]) Poll() *T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if next := q.items.Peek(); next == nil || next.readyAt.After(q.clock.Now()) {
+		return nil
+	}
+	return &q.items.Pop().item
+}
+
+// Take removes and returns the earliest item, waiting until its ready time arrives.
+// It returns the context's error if the context is done before an item becomes available.
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		var timer Timer
+		var fired <-chan time.Time
+		if next := q.items.Peek(); next != nil {
+			wait := next.readyAt.Sub(q.clock.Now())
+			if wait <= 0 {
+				item := q.items.Pop().item
+				q.mu.Unlock()
+				return item, nil
+			}
+			timer = q.clock.NewTimer(wait)
+			fired = timer.C()
+		}
+		changed := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			var zero T
+			return zero, ctx.Err()
+		case <-fired:
+		case <-changed:
+			if timer != nil {
+				timer.Stop()
+			}
+		}
+	}
+}
+
+// Peek returns a pointer to a copy of the earliest item, whether or not it is ready. If the queue is empty,
+// it returns nil.
+func (q *DelayQueue[T]) Peek() *T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	next := q.items.Peek()
+	if next == nil {
+		return nil
+	}
+	item := next.item
+	return &item
+}
+
+// NextReadyAt returns the ready time of the earliest item. The second result is false if the queue is empty.
+func (q *DelayQueue[T]) NextReadyAt() (time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if next := q.items.Peek(); next != nil {
+		return next.readyAt, true
+	}
+	return time.Time{}, false
+}
+
+// Length returns the number of items in the queue, including the ones that are not ready yet.
+func (q *DelayQueue[T]) Length() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.items.Length()
+}
+
+// IsEmpty returns true if the queue has no items, false otherwise.
+func (q *DelayQueue[T]) IsEmpty() bool {
+	return q.Length() == 0
+}
+
+// Clear removes all items from the queue.
+func (q *DelayQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.items.Clear()
+}
+
+// All returns a function that calls yield with every item of the queue, ready or not, in no particular order,
+// until yield returns false. It iterates over a snapshot, so yield may use the queue.
+func (q *DelayQueue[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		q.mu.Lock()
+		items := make([]T, 0, q.items.Length())
+		for _, entry := range q.items.items {
+			items = append(items, entry.item)
+		}
+		q.mu.Unlock()
+
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// delayItemLess orders the items by ready time and insertion sequence.
+func delayItemLess[T any](a, b delayItem[T]) bool {
+	if a.readyAt.Equal(b.readyAt) {
+		return a.seq < b.seq
+	}
+	return a.readyAt.Before(b.readyAt)
+}
@@ -0,0 +1,190 @@
+package l
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var delayEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestDelayQueue_Poll(t *testing.T) {
+	type push struct {
+		item  string
+		delay time.Duration
+	}
+
+	tests := []struct {
+		name    string
+		pushes  []push
+		advance time.Duration
+		want    []string
+		left    int
+	}{
+		{
+			name:    "empty queue",
+			pushes:  []push{},
+			advance: time.Hour,
+			want:    []string{},
+		},
+		{
+			name:    "nothing ready",
+			pushes:  []push{{"a", time.Minute}, {"b", time.Second}},
+			advance: 0,
+			want:    []string{},
+			left:    2,
+		},
+		{
+			name:    "ordered by ready time",
+			pushes:  []push{{"a", 3 * time.Second}, {"b", time.Second}, {"c", 2 * time.Second}},
+			advance: 3 * time.Second,
+			want:    []string{"b", "c", "a"},
+		},
+		{
+			name:    "same ready time keeps push order",
+			pushes:  []push{{"a", time.Second}, {"b", time.Second}, {"c", time.Second}},
+			advance: time.Second,
+			want:    []string{"a", "b", "c"},
+		},
+		{
+			name:    "partially ready",
+			pushes:  []push{{"a", time.Second}, {"b", time.Hour}, {"c", 0}},
+			advance: time.Minute,
+			want:    []string{"c", "a"},
+			left:    1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := NewFakeClock(delayEpoch)
+			q := NewDelayQueueWithClock[string](clock)
+			for _, p := range tc.pushes {
+				q.PushAfter(p.item, p.delay)
+			}
+			clock.Advance(tc.advance)
+
+			got := []string{}
+			for item := q.Poll(); item != nil; item = q.Poll() {
+				got = append(got, *item)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Poll() sequence = %v, want %v", got, tc.want)
+			}
+			if q.Length() != tc.left {
+				t.Errorf("Length() = %d, want %d", q.Length(), tc.left)
+			}
+		})
+	}
+}
+
+func TestDelayQueue_Peek(t *testing.T) {
+	clock := NewFakeClock(delayEpoch)
+	q := NewDelayQueueWithClock[int](clock)
+
+	if got := q.Peek(); got != nil {
+		t.Errorf("Peek() on empty queue = %v, want nil", *got)
+	}
+	if _, ok := q.NextReadyAt(); ok {
+		t.Errorf("NextReadyAt() on empty queue returned ok")
+	}
+
+	q.PushAfter(1, time.Minute)
+	q.PushAfter(2, time.Second)
+	if got := q.Peek(); got == nil || *got != 2 {
+		t.Errorf("Peek() = %v, want 2", got)
+	}
+	if got, ok := q.NextReadyAt(); !ok || !got.Equal(delayEpoch.Add(time.Second)) {
+		t.Errorf("NextReadyAt() = %v, %v, want %v", got, ok, delayEpoch.Add(time.Second))
+	}
+	// The result is a copy, so it does not change when the queue does.
+	peeked := q.Peek()
+	*peeked = 20
+	clock.Advance(time.Minute)
+	q.Poll()
+	if *peeked != 20 {
+		t.Errorf("peeked item changed to %d after Poll", *peeked)
+	}
+	if got := q.Poll(); got == nil || *got != 1 {
+		t.Errorf("Poll() = %v, want 1", got)
+	}
+}
+
+func TestDelayQueue_Take(t *testing.T) {
+	clock := NewFakeClock(delayEpoch)
+	q := NewDelayQueueWithClock[string](clock)
+	q.PushAfter("late", time.Minute)
+
+	result := make(chan string)
+	go func() {
+		item, err := q.Take(context.Background())
+		if err != nil {
+			t.Errorf("Take() error = %v", err)
+		}
+		result <- item
+	}()
+
+	// Take sleeps until the only item is ready. Pushing an earlier item wakes it up to wait for that one instead.
+	clock.BlockUntil(1)
+	q.PushAfter("early", time.Second)
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+
+	if got := <-result; got != "early" {
+		t.Errorf("Take() = %v, want early", got)
+	}
+	if got := q.Length(); got != 1 {
+		t.Errorf("Length() = %d, want 1", got)
+	}
+}
+
+func TestDelayQueue_TakeEmpty(t *testing.T) {
+	clock := NewFakeClock(delayEpoch)
+	q := NewDelayQueueWithClock[int](clock)
+
+	result := make(chan int)
+	go func() {
+		item, _ := q.Take(context.Background())
+		result <- item
+	}()
+
+	q.PushAfter(42, 0)
+	if got := <-result; got != 42 {
+		t.Errorf("Take() = %v, want 42", got)
+	}
+}
+
+func TestDelayQueue_TakeCanceled(t *testing.T) {
+	clock := NewFakeClock(delayEpoch)
+	q := NewDelayQueueWithClock[int](clock)
+	q.PushAfter(1, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		clock.BlockUntil(1)
+		cancel()
+	}()
+
+	if _, err := q.Take(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Take() error = %v, want %v", err, context.Canceled)
+	}
+	if got := clock.Timers(); got != 0 {
+		t.Errorf("Timers() after cancel = %d, want 0", got)
+	}
+	if got := q.Length(); got != 1 {
+		t.Errorf("Length() = %d, want 1", got)
+	}
+}
+
+func TestDelayQueue_SystemClock(t *testing.T) {
+	q := NewDelayQueue[int]()
+	q.PushAfter(1, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if got, err := q.Take(ctx); err != nil || got != 1 {
+		t.Errorf("Take() = %v, %v, want 1", got, err)
+	}
+}
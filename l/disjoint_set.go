@@ -0,0 +1,205 @@
+package l
+
+import "sort"
+
+// DisjointSet is a union-find structure that partitions items into disjoint sets.
+// Find and Union use path compression and union by rank, so they run in nearly constant amortized time.
+// The items are kept in insertion order, which makes the enumeration of the sets deterministic.
+type DisjointSet[T comparable] struct {
+	indexes map[T]int
+	items   []T
+	parent  []int
+	rank    []uint8
+	// next links the members of every set into a circular list, so a set can be enumerated without
+	// scanning all items.
+	next  []int
+	count int
+}
+
+// NewDisjointSet creates a DisjointSet where every given item is in its own set.
+//
+// Example usage:
+//
+//	d := NewDisjointSet(1, 2, 3, 4)
+//	d.Union(1, 2)
+//	d.Union(3, 4)
+//	d.Connected(1, 2) // true
+//	d.Connected(2, 3) // false
+//	d.SetCount() // 2
+func NewDisjointSet[T comparable](items ...T) DisjointSet[T] {
+	d := DisjointSet[T]{}
+	d.MakeSet(items...)
+	return d
+}
+
+// MakeSet adds every item that is not yet in the structure as a new set with a single member.
+// It returns the number of added items.
+func (d *DisjointSet[T]) MakeSet(items ...T) int {
+	if d.indexes == nil {
+		d.indexes = make(map[T]int, len(items))
+	}
+
+	added := 0
+	for _, item := range items {
+		if _, ok := d.indexes[item]; !ok {
+			d.add(item)
+			added++
+		}
+	}
+	return added
+}
+
+// Find returns a pointer to the representative of the set that contains the item.
+// Two items are in the same set if they have the same representative. If the item is not in the structure,
+// it returns nil.
+func (d *DisjointSet[T]) Find(item T) *T {
+	index, ok := d.indexes[item]
+	if !ok {
+		return nil
+	}
+
+	root := d.items[d.find(index)]
+	return &root
+}
+
+// Union merges the sets that contain a and b. Items that are not in the structure are added first.
+// It returns true if the items were in different sets before.
+func (d *DisjointSet[T]) Union(a, b T) bool {
+	d.MakeSet(a, b)
+	x, y := d.find(d.indexes[a]), d.find(d.indexes[b])
+	if x == y {
+		return false
+	}
+
+	if d.rank[x] < d.rank[y] {
+		x, y = y, x
+	}
+	d.parent[y] = x
+	if d.rank[x] == d.rank[y] {
+		d.rank[x]++
+	}
+	d.next[x], d.next[y] = d.next[y], d.next[x]
+	d.count--
+	return true
+}
+
+// Connected returns true if a and b are in the same set. Items that are not in the structure are not connected
+// to anything.
+func (d *DisjointSet[T]) Connected(a, b T) bool {
+	x, ok := d.indexes[a]
+	if !ok {
+		return false
+	}
+	y, ok := d.indexes[b]
+	return ok && d.find(x) == d.find(y)
+}
+
+// Length returns the number of items in the structure.
+func (d *DisjointSet[T]) Length() int {
+	return len(d.items)
+}
+
+// IsEmpty returns true if the structure has no items, false otherwise.
+func (d *DisjointSet[T]) IsEmpty() bool {
+	return len(d.items) == 0
+}
+
+// All returns a function that calls yield with every item in insertion order, until yield returns false.
+func (d *DisjointSet[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for _, item := range d.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// SetCount returns the number of disjoint sets.
+func (d *DisjointSet[T]) SetCount() int {
+	return d.count
+}
+
+// SetSize returns the number of members of the set that contains the item, or 0 if the item is not
+// in the structure.
+func (d *DisjointSet[T]) SetSize(item T) int {
+	size := 0
+	d.walk(item, func(int) { size++ })
+	return size
+}
+
+// Members returns a List with the members of the set that contains the item, in insertion order.
+// If the item is not in the structure, the List is empty.
+func (d *DisjointSet[T]) Members(item T) *List[T] {
+	indexes := []int{}
+	d.walk(item, func(index int) { indexes = append(indexes, index) })
+	sort.Ints(indexes)
+
+	members := NewList[T]()
+	for _, index := range indexes {
+		members.Add(d.items[index])
+	}
+	return &members
+}
+
+// Sets returns a List with all sets. The sets are ordered by their first inserted member, and the members of
+// every set are in insertion order.
+func (d *DisjointSet[T]) Sets() *List[List[T]] {
+	sets := NewList[List[T]]()
+	positions := make(map[int]int, d.count)
+	for index, item := range d.items {
+		root := d.find(index)
+		position, ok := positions[root]
+		if !ok {
+			position = sets.Length()
+			positions[root] = position
+			sets.Add(NewList[T]())
+		}
+		sets.Get(position).Add(item)
+	}
+	return &sets
+}
+
+// Clear removes all items from the structure.
+func (d *DisjointSet[T]) Clear() {
+	*d = DisjointSet[T]{}
+}
+
+func (d *DisjointSet[T]) add(item T) {
+	index := len(d.items)
+	d.indexes[item] = index
+	d.items = append(d.items, item)
+	d.parent = append(d.parent, index)
+	d.rank = append(d.rank, 0)
+	d.next = append(d.next, index)
+	d.count++
+}
+
+// find returns the index of the root of the given index and points every node on the path directly to it.
+func (d *DisjointSet[T]) find(index int) int {
+	root := index
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	for d.parent[index] != root {
+		d.parent[index], index = root, d.parent[index]
+	}
+	return root
+}
+
+// walk calls f with the index of every member of the set that contains the item.
+func (d *DisjointSet[T]) walk(item T, f func(index int)) {
+	start, ok := d.indexes[item]
+	if !ok {
+		return
+	}
+
+	index := start
+	for {
+		f(index)
+		index = d.next[index]
+		if index == start {
+			return
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package l
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDisjointSet_Union(t *testing.T) {
+	tests := []struct {
+		name      string
+		unions    [][2]string
+		wantCount int
+		wantSets  [][]string
+	}{
+		{
+			name:      "no unions",
+			wantCount: 4,
+			wantSets:  [][]string{{"a"}, {"b"}, {"c"}, {"d"}},
+		},
+		{
+			name:      "two pairs",
+			unions:    [][2]string{{"a", "c"}, {"d", "b"}},
+			wantCount: 2,
+			wantSets:  [][]string{{"a", "c"}, {"b", "d"}},
+		},
+		{
+			name:      "chain with repeated union",
+			unions:    [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}},
+			wantCount: 1,
+			wantSets:  [][]string{{"a", "b", "c", "d"}},
+		},
+		{
+			name:      "union adds new items",
+			unions:    [][2]string{{"e", "a"}},
+			wantCount: 4,
+			wantSets:  [][]string{{"a", "e"}, {"b"}, {"c"}, {"d"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDisjointSet("a", "b", "c", "d")
+			for _, u := range tc.unions {
+				d.Union(u[0], u[1])
+			}
+
+			if d.SetCount() != tc.wantCount {
+				t.Errorf("SetCount() = %d, want %d", d.SetCount(), tc.wantCount)
+			}
+			got := [][]string{}
+			sets := d.Sets()
+			sets.ForEach(func(_ int, set List[string]) { got = append(got, set.Slice()) })
+			if !reflect.DeepEqual(got, tc.wantSets) {
+				t.Errorf("Sets() = %v, want %v", got, tc.wantSets)
+			}
+			for _, set := range tc.wantSets {
+				for _, item := range set {
+					if !d.Connected(set[0], item) {
+						t.Errorf("Connected(%q, %q) = false, want true", set[0], item)
+					}
+					if *d.Find(item) != *d.Find(set[0]) {
+						t.Errorf("Find(%q) differs from Find(%q)", item, set[0])
+					}
+					if d.SetSize(item) != len(set) {
+						t.Errorf("SetSize(%q) = %d, want %d", item, d.SetSize(item), len(set))
+					}
+					if members := d.Members(item).Slice(); !reflect.DeepEqual(members, set) {
+						t.Errorf("Members(%q) = %v, want %v", item, members, set)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDisjointSet_Missing(t *testing.T) {
+	d := NewDisjointSet(1, 2)
+
+	if d.Find(3) != nil {
+		t.Errorf("Find() of missing item is not nil")
+	}
+	if d.Connected(1, 3) || d.Connected(3, 3) {
+		t.Errorf("Connected() of missing item = true, want false")
+	}
+	if d.SetSize(3) != 0 || !d.Members(3).IsEmpty() {
+		t.Errorf("missing item has members")
+	}
+	if d.MakeSet(1, 3, 3) != 1 || d.Length() != 3 {
+		t.Errorf("MakeSet() added duplicates, Length() = %d", d.Length())
+	}
+	if d.Union(1, 1) {
+		t.Errorf("Union() of the same item = true, want false")
+	}
+
+	d.Clear()
+	if d.Length() != 0 || d.SetCount() != 0 || d.Find(1) != nil {
+		t.Errorf("structure not empty after Clear")
+	}
+	d.Union(5, 6)
+	if !d.Connected(5, 6) {
+		t.Errorf("Union() after Clear did not connect the items")
+	}
+}
+
+func TestDisjointSet_LongChain(t *testing.T) {
+	var d DisjointSet[int]
+	const n = 10000
+	for i := 1; i < n; i++ {
+		d.Union(i-1, i)
+	}
+	if d.SetCount() != 1 || d.SetSize(n/2) != n {
+		t.Errorf("SetCount() = %d, SetSize() = %d, want 1, %d", d.SetCount(), d.SetSize(n/2), n)
+	}
+	if !d.Connected(0, n-1) {
+		t.Errorf("Connected(0, %d) = false, want true", n-1)
+	}
+}
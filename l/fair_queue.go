@@ -0,0 +1,377 @@
+package l
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ErrKeyFull is returned by FairQueue.Push when the sub-queue of the key has reached its limit.
+var ErrKeyFull = errors.New("l: queue of the key is full")
+
+// FairScheduling selects how a FairQueue chooses the key of the next item.
+type FairScheduling int
+
+const (
+	// WeightedRoundRobin visits the keys in turn and pops up to weight items of a key per turn.
+	WeightedRoundRobin FairScheduling = iota
+	// DeficitRoundRobin visits the keys in turn and gives every key a credit of weight per turn, which it spends
+	// on the cost of its items. Unused credit carries over to the next turn while the key has items, so keys get
+	// a share of the total cost proportional to their weight even when the costs of the items differ.
+	DeficitRoundRobin
+)
+
+// FairQueueStats are the counters of a key of a FairQueue.
+type FairQueueStats struct {
+	// Length is the number of queued items of the key.
+	Length int
+	// Pushed, Popped and Rejected count the items of the key that were added, removed by Pop or Take,
+	// and rejected because of the limit of the key.
+	Pushed   int
+	Popped   int
+	Rejected int
+}
+
+// FairQueue is a queue that keeps a sub-queue of items per key, like per tenant, and pops the items of the keys
+// in turn, so a key with many items cannot starve the others. Within a key, the items are popped in the order
+// they were pushed. A key takes part in the rotation while it has items and leaves it when its sub-queue is empty;
+// its weight, limit and stats are kept. A FairQueue is safe for concurrent use.
+type FairQueue[K comparable, T any] struct {
+	mu         sync.Mutex
+	scheduling FairScheduling
+	cost       func(T) int
+	// ring holds the keys with items in rotation order, and current is the index of the key whose turn it is.
+	ring    []*fairSubQueue[K, T]
+	current int
+	queues  map[K]*fairSubQueue[K, T]
+	length  int
+	weights map[K]int
+	limits  map[K]int
+	stats   map[K]*FairQueueStats
+	changed chan struct{}
+}
+
+type fairSubQueue[K comparable, T any] struct {
+	key   K
+	items Queue[T]
+	// served counts the items popped in the current turn of WeightedRoundRobin.
+	served int
+	// deficit is the unused credit of DeficitRoundRobin, and started is true once the credit of the current turn
+	// was added.
+	deficit int
+	started bool
+}
+
+// NewFairQueue creates an empty FairQueue with the given scheduling. Every key has a weight of 1, no limit,
+// and with DeficitRoundRobin every item costs 1 until SetCost is called.
+//
+// Example usage:
+//
+//	q := NewFairQueue[string, Job](WeightedRoundRobin)
+//	q.SetWeight("premium", 3)
+//	q.SetLimit("free", 100)
+//	q.Push("free", job)
+//	entry, err := q.Take(ctx) // entry.Key is the tenant, entry.Value the job
+func NewFairQueue[K comparable, T any](scheduling FairScheduling) *FairQueue[K, T] {
+	return &FairQueue[K, T]{
+		scheduling: scheduling,
+		cost:       func(T) int { return 1 },
+		queues:     make(map[K]*fairSubQueue[K, T]),
+		weights:    make(map[K]int),
+		limits:     make(map[K]int),
+		stats:      make(map[K]*FairQueueStats),
+		changed:    make(chan struct{}),
+	}
+}
+
+// SetWeight sets the weight of the key, which is the number of items per turn with WeightedRoundRobin and the
+// credit per turn with DeficitRoundRobin. It panics if the weight is less than 1.
+func (q *FairQueue[K, T]) SetWeight(key K, weight int) {
+	if weight < 1 {
+		panic("l: weight must be positive")
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.weights[key] = weight
+}
+
+// SetLimit sets the maximum number of queued items of the key. A limit of 0 removes the limit.
+func (q *FairQueue[K, T]) SetLimit(key K, limit int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if limit <= 0 {
+		delete(q.limits, key)
+		return
+	}
+	q.limits[key] = limit
+}
+
+// SetCost sets the function that returns the cost of an item for DeficitRoundRobin, like its size in bytes.
+// The cost must be positive, otherwise Pop panics.
+func (q *FairQueue[K, T]) SetCost(cost func(T) int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.cost = cost
+}
+
+// Push adds the item to the end of the sub-queue of the key. If the sub-queue has reached the limit of the key,
+// it returns ErrKeyFull and does not add the item.
+func (q *FairQueue[K, T]) Push(key K, item T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stats := q.statsOf(key)
+	sub := q.queues[key]
+	if limit, ok := q.limits[key]; ok && sub != nil && sub.items.Length() >= limit {
+		stats.Rejected++
+		return ErrKeyFull
+	}
+
+	if sub == nil {
+		sub = &fairSubQueue[K, T]{key: key}
+		q.queues[key] = sub
+		q.ring = append(q.ring, sub)
+	}
+	sub.items.Push(item)
+	stats.Pushed++
+	q.length++
+
+	close(q.changed)
+	q.changed = make(chan struct{})
+	return nil
+}
+
+// Pop removes and returns the next item with its key according to the scheduling. If the queue is empty,
+// it returns nil.
+func (q *FairQueue[K, T]) Pop() *Entry[K, T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.pop()
+}
+
+// Take removes and returns the next item with its key according to the scheduling, waiting until an item is
+// pushed if the queue is empty. It returns the context's error if the context is done before.
+func (q *FairQueue[K, T]) Take(ctx context.Context) (Entry[K, T], error) {
+	for {
+		entry, changed := q.tryPop()
+		if entry != nil {
+			return *entry, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return Entry[K, T]{}, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// RemoveKey removes the key with its queued items, weight, limit and stats. It returns the number of removed items.
+func (q *FairQueue[K, T]) RemoveKey(key K) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	removed := 0
+	if sub := q.queues[key]; sub != nil {
+		removed = sub.items.Length()
+		q.length -= removed
+		q.remove(slices.Index(q.ring, sub))
+	}
+	delete(q.weights, key)
+	delete(q.limits, key)
+	delete(q.stats, key)
+	return removed
+}
+
+// Stats returns the counters of the key. For a key that was never used, all counters are 0.
+func (q *FairQueue[K, T]) Stats(key K) FairQueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var stats FairQueueStats
+	if s := q.stats[key]; s != nil {
+		stats = *s
+	}
+	if sub := q.queues[key]; sub != nil {
+		stats.Length = sub.items.Length()
+	}
+	return stats
+}
+
+// Keys returns a List with the keys that have queued items, in rotation order.
+func (q *FairQueue[K, T]) Keys() *List[K] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	keys := NewListWithCapacity[K](len(q.ring))
+	for _, sub := range q.ring {
+		keys.Add(sub.key)
+	}
+	return &keys
+}
+
+// Length returns the number of items in the queue.
+func (q *FairQueue[K, T]) Length() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.length
+}
+
+// IsEmpty returns true if the queue has no items, false otherwise.
+func (q *FairQueue[K, T]) IsEmpty() bool {
+	return q.Length() == 0
+}
+
+// Clear removes all items from the queue. The weights, limits and stats of the keys are kept.
+func (q *FairQueue[K, T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.ring, q.current, q.length = nil, 0, 0
+	clear(q.queues)
+}
+
+// All returns a function that calls yield with every item and its key, key by key in rotation order,
+// until yield returns false. It iterates over a snapshot, so yield may use the queue.
+func (q *FairQueue[K, T]) All() func(yield func(Entry[K, T]) bool) {
+	return func(yield func(Entry[K, T]) bool) {
+		q.mu.Lock()
+		entries := make([]Entry[K, T], 0, q.length)
+		for _, sub := range q.ring {
+			for _, item := range sub.items.items {
+				entries = append(entries, Entry[K, T]{Key: sub.key, Value: item})
+			}
+		}
+		q.mu.Unlock()
+
+		for _, entry := range entries {
+			if !yield(entry) {
+				return
+			}
+		}
+	}
+}
+
+// tryPop removes the next item. If the queue is empty, it returns the channel that is closed by the next Push.
+func (q *FairQueue[K, T]) tryPop() (*Entry[K, T], chan struct{}) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if entry := q.pop(); entry != nil {
+		return entry, nil
+	}
+	return nil, q.changed
+}
+
+// pop removes the next item. It must be called with the lock held.
+func (q *FairQueue[K, T]) pop() *Entry[K, T] {
+	if q.length == 0 {
+		return nil
+	}
+	if q.scheduling == DeficitRoundRobin {
+		return q.popDeficit()
+	}
+
+	sub := q.ring[q.current]
+	entry := q.take(sub)
+	if sub.served++; sub.items.IsEmpty() {
+		q.remove(q.current)
+	} else if sub.served >= q.weight(sub.key) {
+		sub.served = 0
+		q.current = (q.current + 1) % len(q.ring)
+	}
+	return entry
+}
+
+func (q *FairQueue[K, T]) popDeficit() *Entry[K, T] {
+	for skipped := 0; ; skipped++ {
+		if skipped == len(q.ring) {
+			q.skipRounds()
+		}
+
+		sub := q.ring[q.current]
+		if !sub.started {
+			sub.deficit += q.weight(sub.key)
+			sub.started = true
+		}
+		cost := q.costOf(*sub.items.Peek())
+		if cost > sub.deficit {
+			// The credit is not enough for the next item, so the turn passes to the next key.
+			sub.started = false
+			q.current = (q.current + 1) % len(q.ring)
+			continue
+		}
+
+		entry := q.take(sub)
+		sub.deficit -= cost
+		if sub.items.IsEmpty() {
+			q.remove(q.current)
+		}
+		return entry
+	}
+}
+
+// skipRounds is called when every key passed its turn without popping an item, because the costs of the items are
+// larger than the weights. It adds the credit of the turns that would pass without any pop to every key at once,
+// so the next round pops an item.
+func (q *FairQueue[K, T]) skipRounds() {
+	rounds := -1
+	for _, sub := range q.ring {
+		weight := q.weight(sub.key)
+		needed := (q.costOf(*sub.items.Peek()) - sub.deficit + weight - 1) / weight
+		if rounds < 0 || needed < rounds {
+			rounds = needed
+		}
+	}
+	for _, sub := range q.ring {
+		sub.deficit += (rounds - 1) * q.weight(sub.key)
+	}
+}
+
+func (q *FairQueue[K, T]) take(sub *fairSubQueue[K, T]) *Entry[K, T] {
+	q.length--
+	q.stats[sub.key].Popped++
+	return &Entry[K, T]{Key: sub.key, Value: *sub.items.Pop()}
+}
+
+// remove removes the sub-queue at the index from the rotation. The turn passes to the next key.
+func (q *FairQueue[K, T]) remove(index int) {
+	delete(q.queues, q.ring[index].key)
+	q.ring = slices.Delete(q.ring, index, index+1)
+	if index < q.current {
+		q.current--
+	}
+	if q.current >= len(q.ring) {
+		q.current = 0
+	}
+}
+
+func (q *FairQueue[K, T]) weight(key K) int {
+	if weight, ok := q.weights[key]; ok {
+		return weight
+	}
+	return 1
+}
+
+func (q *FairQueue[K, T]) costOf(item T) int {
+	cost := q.cost(item)
+	if cost < 1 {
+		panic("l: cost must be positive")
+	}
+	return cost
+}
+
+func (q *FairQueue[K, T]) statsOf(key K) *FairQueueStats {
+	stats := q.stats[key]
+	if stats == nil {
+		stats = &FairQueueStats{}
+		q.stats[key] = stats
+	}
+	return stats
+}
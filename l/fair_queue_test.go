@@ -0,0 +1,234 @@
+package l
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// drain pops all items of the queue and returns their keys in order.
+func drain[T any](q *FairQueue[string, T]) []string {
+	keys := []string{}
+	for entry := q.Pop(); entry != nil; entry = q.Pop() {
+		keys = append(keys, entry.Key)
+	}
+	return keys
+}
+
+func TestFairQueue_WeightedRoundRobin(t *testing.T) {
+	tests := []struct {
+		name     string
+		weights  map[string]int
+		pushes   []string
+		expected []string
+	}{
+		{
+			name:     "equal weights alternate",
+			pushes:   []string{"a", "a", "a", "b", "c"},
+			expected: []string{"a", "b", "c", "a", "a"},
+		},
+		{
+			name:     "weights give consecutive items",
+			weights:  map[string]int{"a": 2, "b": 1},
+			pushes:   []string{"a", "a", "a", "a", "b", "b"},
+			expected: []string{"a", "a", "b", "a", "a", "b"},
+		},
+		{
+			name:     "a noisy key does not starve the others",
+			pushes:   []string{"noisy", "noisy", "noisy", "noisy", "quiet"},
+			expected: []string{"noisy", "quiet", "noisy", "noisy", "noisy"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewFairQueue[string, int](WeightedRoundRobin)
+			for key, weight := range tc.weights {
+				q.SetWeight(key, weight)
+			}
+			for i, key := range tc.pushes {
+				if err := q.Push(key, i); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := drain(q); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("popped keys %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFairQueue_ItemsInOrder(t *testing.T) {
+	q := NewFairQueue[string, int](WeightedRoundRobin)
+	q.Push("a", 1)
+	q.Push("b", 10)
+	q.Push("a", 2)
+
+	expected := []Entry[string, int]{{Key: "a", Value: 1}, {Key: "a", Value: 2}, {Key: "b", Value: 10}}
+	if got := ToList[Entry[string, int]](q).Slice(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("All() = %v, want %v", got, expected)
+	}
+
+	expected = []Entry[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 10}, {Key: "a", Value: 2}}
+	for _, want := range expected {
+		if got := q.Pop(); got == nil || *got != want {
+			t.Errorf("Pop() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFairQueue_DeficitRoundRobin(t *testing.T) {
+	q := NewFairQueue[string, int](DeficitRoundRobin)
+	q.SetCost(func(size int) int { return size })
+	q.SetWeight("small", 100)
+	q.SetWeight("large", 100)
+
+	// "large" sends items that cost 300, "small" items that cost 100, so both get the same total cost.
+	for i := 0; i < 6; i++ {
+		q.Push("small", 100)
+	}
+	for i := 0; i < 2; i++ {
+		q.Push("large", 300)
+	}
+
+	expected := []string{"small", "small", "small", "large", "small", "small", "small", "large"}
+	if got := drain(q); !reflect.DeepEqual(got, expected) {
+		t.Errorf("popped keys %v, want %v", got, expected)
+	}
+}
+
+func TestFairQueue_DeficitRoundRobin_LargeCosts(t *testing.T) {
+	q := NewFairQueue[string, int](DeficitRoundRobin)
+	q.SetCost(func(size int) int { return size })
+	q.Push("a", 1_000_000_000)
+	q.Push("b", 10)
+	q.Push("b", 10)
+	q.SetWeight("b", 5)
+
+	// "b" pops after two turns and its second item after two more, long before "a" has enough credit.
+	done := make(chan []string)
+	go func() { done <- drain(q) }()
+	select {
+	case got := <-done:
+		if expected := []string{"b", "b", "a"}; !reflect.DeepEqual(got, expected) {
+			t.Errorf("popped keys %v, want %v", got, expected)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Pop() did not skip the rounds without pops")
+	}
+
+	q.SetCost(func(int) int { return 0 })
+	q.Push("a", 1)
+	assertPanics(t, "Pop() with a cost of 0", func() { q.Pop() })
+	assertPanics(t, "Take() with a cost of 0", func() { q.Take(context.Background()) })
+
+	// The panics must not leave the queue locked.
+	done = make(chan []string)
+	go func() {
+		q.SetCost(func(int) int { return 1 })
+		done <- drain(q)
+	}()
+	select {
+	case got := <-done:
+		if expected := []string{"a"}; !reflect.DeepEqual(got, expected) {
+			t.Errorf("popped keys %v, want %v", got, expected)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the queue stayed locked after a panic")
+	}
+}
+
+func TestFairQueue_LimitsAndStats(t *testing.T) {
+	q := NewFairQueue[string, int](WeightedRoundRobin)
+	q.SetLimit("a", 2)
+
+	for i := 0; i < 3; i++ {
+		err := q.Push("a", i)
+		if i < 2 && err != nil || i == 2 && !errors.Is(err, ErrKeyFull) {
+			t.Errorf("Push() #%d = %v", i, err)
+		}
+	}
+	q.Push("b", 0)
+	q.Pop()
+
+	if got, want := q.Stats("a"), (FairQueueStats{Length: 1, Pushed: 2, Popped: 1, Rejected: 1}); got != want {
+		t.Errorf("Stats(a) = %+v, want %+v", got, want)
+	}
+	if got := q.Stats("unknown"); got != (FairQueueStats{}) {
+		t.Errorf("Stats(unknown) = %+v, want zero", got)
+	}
+
+	// After a Pop, there is room for one more item.
+	if err := q.Push("a", 3); err != nil {
+		t.Errorf("Push() after Pop = %v", err)
+	}
+	q.SetLimit("a", 0)
+	if err := q.Push("a", 4); err != nil {
+		t.Errorf("Push() without limit = %v", err)
+	}
+	assertPanics(t, "SetWeight(0)", func() { q.SetWeight("a", 0) })
+}
+
+func TestFairQueue_Keys(t *testing.T) {
+	q := NewFairQueue[string, int](WeightedRoundRobin)
+	q.Push("a", 1)
+	q.Push("b", 2)
+	q.Push("c", 3)
+	q.Push("c", 4)
+
+	if got := q.Keys().Slice(); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Keys() = %v, want [a b c]", got)
+	}
+
+	// A key leaves the rotation when its sub-queue is empty, but keeps its stats.
+	q.Pop()
+	if got := q.Keys().Slice(); !reflect.DeepEqual(got, []string{"b", "c"}) {
+		t.Errorf("Keys() = %v, want [b c]", got)
+	}
+	if got := q.Stats("a").Popped; got != 1 {
+		t.Errorf("Stats(a).Popped = %d, want 1", got)
+	}
+
+	if removed := q.RemoveKey("c"); removed != 2 {
+		t.Errorf("RemoveKey(c) = %d, want 2", removed)
+	}
+	if q.Length() != 1 || q.Stats("c") != (FairQueueStats{}) {
+		t.Errorf("Length() = %d, Stats(c) = %+v after RemoveKey", q.Length(), q.Stats("c"))
+	}
+	if got := q.Pop(); got == nil || got.Key != "b" {
+		t.Errorf("Pop() = %v, want b", got)
+	}
+
+	q.Push("d", 5)
+	q.Clear()
+	if !q.IsEmpty() || q.Pop() != nil || q.Keys().Length() != 0 {
+		t.Errorf("queue not empty after Clear")
+	}
+	if got := q.Stats("d").Pushed; got != 1 {
+		t.Errorf("Clear removed the stats")
+	}
+}
+
+func TestFairQueue_Take(t *testing.T) {
+	q := NewFairQueue[string, int](WeightedRoundRobin)
+
+	taken := make(chan Entry[string, int])
+	go func() {
+		entry, _ := q.Take(context.Background())
+		taken <- entry
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	q.Push("a", 1)
+	if got := <-taken; got != (Entry[string, int]{Key: "a", Value: 1}) {
+		t.Errorf("Take() = %v, want a: 1", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Take() = %v, want context.DeadlineExceeded", err)
+	}
+}
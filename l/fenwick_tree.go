@@ -0,0 +1,71 @@
+package l
+
+// FenwickTree is a binary indexed tree that maintains prefix sums of a sequence of numbers.
+// Add, Set, PrefixSum and RangeSum run in O(log n), and the tree uses no more memory than the numbers themselves.
+type FenwickTree[T Number] struct {
+	// sums[i-1] holds the sum of the items in (i - lowbit(i), i].
+	sums []T
+}
+
+// NewFenwickTree creates a FenwickTree over the items.
+//
+// Example usage:
+//
+//	t := NewFenwickTree(3, 1, 4, 1, 5)
+//	t.PrefixSum(3) // 8
+//	t.Add(1, 10)
+//	t.RangeSum(1, 3) // 15
+func NewFenwickTree[T Number](items ...T) FenwickTree[T] {
+	t := FenwickTree[T]{sums: append([]T(nil), items...)}
+	for i := 1; i <= len(t.sums); i++ {
+		if parent := i + i&-i; parent <= len(t.sums) {
+			t.sums[parent-1] += t.sums[i-1]
+		}
+	}
+	return t
+}
+
+// Length returns the number of items in the tree.
+func (t *FenwickTree[T]) Length() int {
+	return len(t.sums)
+}
+
+// Add adds the delta to the item at the index. It panics if the index is out of range.
+func (t *FenwickTree[T]) Add(index int, delta T) {
+	if index < 0 || index >= len(t.sums) {
+		panic("l: FenwickTree index out of range")
+	}
+	for i := index + 1; i <= len(t.sums); i += i & -i {
+		t.sums[i-1] += delta
+	}
+}
+
+// Set replaces the item at the index. It panics if the index is out of range.
+func (t *FenwickTree[T]) Set(index int, item T) {
+	t.Add(index, item-t.Get(index))
+}
+
+// Get returns the item at the index. It panics if the index is out of range.
+func (t *FenwickTree[T]) Get(index int) T {
+	if index < 0 || index >= len(t.sums) {
+		panic("l: FenwickTree index out of range")
+	}
+	return t.RangeSum(index, index+1)
+}
+
+// PrefixSum returns the sum of the first n items. The count is clamped to the items of the tree.
+func (t *FenwickTree[T]) PrefixSum(n int) T {
+	var sum T
+	for i := min(n, len(t.sums)); i > 0; i -= i & -i {
+		sum += t.sums[i-1]
+	}
+	return sum
+}
+
+// RangeSum returns the sum of the items in the range [from, to).
+func (t *FenwickTree[T]) RangeSum(from, to int) T {
+	if from >= to {
+		return 0
+	}
+	return t.PrefixSum(to) - t.PrefixSum(from)
+}
@@ -0,0 +1,63 @@
+package l
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFenwickTree(t *testing.T) {
+	tree := NewFenwickTree(3, 1, 4, 1, 5, 9, 2, 6)
+
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{name: "prefix of three", got: tree.PrefixSum(3), want: 8},
+		{name: "whole prefix", got: tree.PrefixSum(8), want: 31},
+		{name: "clamped prefix", got: tree.PrefixSum(100), want: 31},
+		{name: "empty prefix", got: tree.PrefixSum(0), want: 0},
+		{name: "range", got: tree.RangeSum(2, 6), want: 19},
+		{name: "empty range", got: tree.RangeSum(4, 2), want: 0},
+		{name: "get", got: tree.Get(5), want: 9},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.got != tc.want {
+				t.Errorf("got %d, want %d", tc.got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFenwickTree_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	reference := make([]float64, 50)
+	tree := NewFenwickTree(reference...)
+
+	for i := 0; i < 1000; i++ {
+		index := rnd.Intn(len(reference))
+		if rnd.Intn(2) == 0 {
+			delta := float64(rnd.Intn(200) - 100)
+			tree.Add(index, delta)
+			reference[index] += delta
+		} else {
+			value := float64(rnd.Intn(100))
+			tree.Set(index, value)
+			reference[index] = value
+		}
+
+		from, to := rnd.Intn(len(reference)), rnd.Intn(len(reference)+1)
+		want := 0.0
+		for j := from; j < to; j++ {
+			want += reference[j]
+		}
+		if got := tree.RangeSum(from, to); got != want {
+			t.Fatalf("RangeSum(%d, %d) = %v, want %v", from, to, got, want)
+		}
+	}
+	if tree.Length() != len(reference) {
+		t.Errorf("Length() = %d, want %d", tree.Length(), len(reference))
+	}
+}
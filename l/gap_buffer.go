@@ -0,0 +1,150 @@
+package l
+
+// GapBuffer is a sequence that keeps a gap of free space at the position of the last edit.
+// Inserting or deleting at the gap runs in O(1) amortized, and moving the gap costs O(d) for a distance d,
+// so a series of edits close to each other, like typing in a text editor, is much cheaper than with a List.
+type GapBuffer[T any] struct {
+	items []T
+	// The gap is items[gapStart:gapEnd]; the items before and after it form the sequence.
+	gapStart int
+	gapEnd   int
+}
+
+// NewGapBuffer creates a GapBuffer with the provided items and the gap at the end.
+//
+// Example usage:
+//
+//	b := NewGapBuffer([]rune("helo")...)
+//	b.Insert(3, 'l')
+//	string(b.Slice()) // "hello"
+func NewGapBuffer[T any](items ...T) GapBuffer[T] {
+	buffer := append([]T(nil), items...)
+	return GapBuffer[T]{items: buffer, gapStart: len(buffer), gapEnd: len(buffer)}
+}
+
+// Length returns the number of items in the buffer.
+func (b *GapBuffer[T]) Length() int {
+	return len(b.items) - (b.gapEnd - b.gapStart)
+}
+
+// IsEmpty returns true if the buffer has no items, false otherwise.
+func (b *GapBuffer[T]) IsEmpty() bool {
+	return b.Length() == 0
+}
+
+// Clear removes all items from the buffer.
+func (b *GapBuffer[T]) Clear() {
+	*b = GapBuffer[T]{}
+}
+
+// Get returns a pointer to the item at the index. Modifying the value through the pointer modifies the item in
+// the buffer, until the buffer is changed. It panics if the index is out of range.
+func (b *GapBuffer[T]) Get(index int) *T {
+	b.check(index, b.Length()-1)
+	if index >= b.gapStart {
+		index += b.gapEnd - b.gapStart
+	}
+	return &b.items[index]
+}
+
+// Set replaces the item at the index. It panics if the index is out of range.
+func (b *GapBuffer[T]) Set(index int, item T) {
+	*b.Get(index) = item
+}
+
+// Insert inserts the items before the item at the index and leaves the gap after them.
+// An index equal to Length appends the items. It panics if the index is out of range.
+func (b *GapBuffer[T]) Insert(index int, items ...T) {
+	b.check(index, b.Length())
+	b.moveGap(index)
+	if b.gapEnd-b.gapStart < len(items) {
+		b.grow(len(items))
+	}
+	b.gapStart += copy(b.items[b.gapStart:], items)
+}
+
+// Add appends the items to the end of the buffer.
+func (b *GapBuffer[T]) Add(items ...T) {
+	b.Insert(b.Length(), items...)
+}
+
+// Delete removes the items in the range [from, to) by widening the gap. It panics if the range is out of bounds.
+func (b *GapBuffer[T]) Delete(from, to int) {
+	b.check(from, b.Length())
+	b.check(to, b.Length())
+	if from >= to {
+		return
+	}
+
+	b.moveGap(from)
+	var zero T
+	for i := b.gapEnd; i < b.gapEnd+to-from; i++ {
+		b.items[i] = zero
+	}
+	b.gapEnd += to - from
+}
+
+// ForEach calls f with the index and value of every item in order.
+func (b *GapBuffer[T]) ForEach(f func(index int, item T)) {
+	for i, item := range b.items[:b.gapStart] {
+		f(i, item)
+	}
+	for i, item := range b.items[b.gapEnd:] {
+		f(b.gapStart+i, item)
+	}
+}
+
+// All returns a function that calls yield with every item of the buffer in order, until yield returns false.
+func (b *GapBuffer[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for i := 0; i < b.Length(); i++ {
+			if !yield(*b.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns a slice with all items of the buffer.
+func (b *GapBuffer[T]) Slice() []T {
+	items := make([]T, 0, b.Length())
+	items = append(items, b.items[:b.gapStart]...)
+	return append(items, b.items[b.gapEnd:]...)
+}
+
+func (b *GapBuffer[T]) check(index, limit int) {
+	if index < 0 || index > limit {
+		panic("l: GapBuffer index out of range")
+	}
+}
+
+// moveGap moves the gap so that it starts at the index, shifting the items in between across it.
+func (b *GapBuffer[T]) moveGap(index int) {
+	var zero T
+	switch {
+	case index < b.gapStart:
+		moved := b.gapStart - index
+		copy(b.items[b.gapEnd-moved:b.gapEnd], b.items[index:b.gapStart])
+		for i := index; i < min(b.gapStart, b.gapEnd-moved); i++ {
+			b.items[i] = zero
+		}
+		b.gapStart, b.gapEnd = index, b.gapEnd-moved
+	case index > b.gapStart:
+		moved := index - b.gapStart
+		copy(b.items[b.gapStart:], b.items[b.gapEnd:b.gapEnd+moved])
+		for i := max(b.gapEnd, index); i < b.gapEnd+moved; i++ {
+			b.items[i] = zero
+		}
+		b.gapStart, b.gapEnd = index, b.gapEnd+moved
+	}
+}
+
+// grow enlarges the gap so that it holds at least the given number of items.
+func (b *GapBuffer[T]) grow(needed int) {
+	size := max(2*len(b.items), len(b.items)+needed, 16)
+	items := make([]T, size)
+	copy(items, b.items[:b.gapStart])
+	tail := len(b.items) - b.gapEnd
+	copy(items[size-tail:], b.items[b.gapEnd:])
+	b.items, b.gapEnd = items, size-tail
+}
@@ -0,0 +1,77 @@
+package l
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestGapBuffer_Edits(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(b *GapBuffer[rune])
+		want string
+	}{
+		{name: "typing", edit: func(b *GapBuffer[rune]) {
+			for i, c := range ", dear" {
+				b.Insert(5+i, c)
+			}
+		}, want: "hello, dear world"},
+		{name: "backspace", edit: func(b *GapBuffer[rune]) {
+			for i := 11; i > 6; i-- {
+				b.Delete(i-1, i)
+			}
+		}, want: "hello "},
+		{name: "jump around", edit: func(b *GapBuffer[rune]) {
+			b.Insert(11, '!')
+			b.Insert(0, '>')
+			b.Delete(1, 2)
+			b.Insert(1, 'H')
+		}, want: ">Hello world!"},
+		{name: "set", edit: func(b *GapBuffer[rune]) { b.Set(6, 'W') }, want: "hello World"},
+		{name: "clear", edit: func(b *GapBuffer[rune]) { b.Clear() }, want: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewGapBuffer([]rune("hello world")...)
+			tc.edit(&b)
+			if got := string(b.Slice()); got != tc.want {
+				t.Errorf("buffer = %q, want %q", got, tc.want)
+			}
+			if b.Length() != len(tc.want) || b.IsEmpty() != (tc.want == "") {
+				t.Errorf("Length() = %d, want %d", b.Length(), len(tc.want))
+			}
+		})
+	}
+}
+
+func TestGapBuffer_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var b GapBuffer[int]
+	reference := []int{}
+	cursor := 0
+
+	for i := 0; i < 5000; i++ {
+		// Edits mostly happen close to the previous one.
+		cursor = max(0, min(len(reference), cursor+rnd.Intn(11)-5))
+		if rnd.Intn(3) == 0 {
+			to := min(len(reference), cursor+rnd.Intn(4))
+			b.Delete(cursor, to)
+			reference = append(reference[:cursor], reference[to:]...)
+		} else {
+			items := []int{rnd.Int(), rnd.Int()}[:1+rnd.Intn(2)]
+			b.Insert(cursor, items...)
+			reference = append(reference[:cursor], append(items, reference[cursor:]...)...)
+		}
+	}
+
+	if !reflect.DeepEqual(b.Slice(), reference) {
+		t.Fatalf("buffer differs from the reference")
+	}
+	b.ForEach(func(index int, item int) {
+		if item != reference[index] || *b.Get(index) != item {
+			t.Fatalf("item %d = %d, want %d", index, item, reference[index])
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package l
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// Hasher computes a 64-bit hash of an item. It is used by the probabilistic structures of this package.
+// Hashes must be deterministic across processes if the structures are serialized and restored elsewhere.
+type Hasher[T any] func(item T) uint64
+
+var (
+	// ErrIncompatible is returned when merging structures whose parameters do not match.
+	ErrIncompatible = errors.New("l: incompatible parameters")
+	// ErrInvalidData is returned by UnmarshalBinary when the data was not produced by MarshalBinary of the same type.
+	ErrInvalidData = errors.New("l: invalid binary data")
+)
+
+// DefaultHasher returns a Hasher based on 64-bit FNV-1a. Strings, byte slices, booleans and numbers are hashed by
+// their binary representation, and their hashes are stable across processes. All other types are hashed by their
+// Go-syntax representation as formatted by fmt ("%#v"), which is stable across processes only for values without
+// pointers, since pointers are formatted as addresses. Use a custom Hasher for such types if the structures are
+// serialized.
+func DefaultHasher[T any]() Hasher[T] {
+	return hashItem[T]
+}
+
+func hashItem[T any](item T) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	switch v := any(item).(type) {
+	case string:
+		_, _ = h.Write([]byte(v))
+	case []byte:
+		_, _ = h.Write(v)
+	case bool:
+		if v {
+			buf[0] = 1
+		}
+		_, _ = h.Write(buf[:1])
+	case int:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		_, _ = h.Write(buf[:])
+	case int8:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		_, _ = h.Write(buf[:])
+	case int16:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		_, _ = h.Write(buf[:])
+	case int32:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		_, _ = h.Write(buf[:])
+	case int64:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		_, _ = h.Write(buf[:])
+	case uint:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		_, _ = h.Write(buf[:])
+	case uint8:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		_, _ = h.Write(buf[:])
+	case uint16:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		_, _ = h.Write(buf[:])
+	case uint32:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		_, _ = h.Write(buf[:])
+	case uint64:
+		binary.LittleEndian.PutUint64(buf[:], v)
+		_, _ = h.Write(buf[:])
+	case float32:
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(float64(v)))
+		_, _ = h.Write(buf[:])
+	case float64:
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		_, _ = h.Write(buf[:])
+	default:
+		_, _ = fmt.Fprintf(h, "%#v", v)
+	}
+	return mix64(h.Sum64())
+}
+
+// mix64 is the finalizer of SplitMix64. It spreads the entropy of the input over all bits of the result.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// hashIndexes derives k indexes in the range [0, m) from a single hash using double hashing.
+func hashIndexes(hash uint64, k int, m uint64, f func(index uint64)) {
+	h1 := hash
+	h2 := mix64(hash^0x9e3779b97f4a7c15) | 1
+	for i := 0; i < k; i++ {
+		f((h1 + uint64(i)*h2) % m)
+	}
+}
+
+// marshalHeader encodes the type tag and the parameters that precede the data of a serialized structure.
+func marshalHeader(tag byte, params ...uint64) []byte {
+	data := make([]byte, 1, 1+8*len(params))
+	data[0] = tag
+	for _, param := range params {
+		data = binary.BigEndian.AppendUint64(data, param)
+	}
+	return data
+}
+
+// unmarshalHeader decodes the header written by marshalHeader and returns the parameters and the remaining data.
+func unmarshalHeader(data []byte, tag byte, count int) ([]uint64, []byte, error) {
+	if len(data) < 1+8*count || data[0] != tag {
+		return nil, nil, ErrInvalidData
+	}
+
+	params := make([]uint64, count)
+	for i := range params {
+		params[i] = binary.BigEndian.Uint64(data[1+8*i:])
+	}
+	return params, data[1+8*count:], nil
+}
@@ -0,0 +1,36 @@
+package l
+
+import "testing"
+
+func TestDefaultHasher(t *testing.T) {
+	type point struct{ X, Y int }
+
+	tests := []struct {
+		name string
+		a, b any
+		same bool
+	}{
+		{name: "equal strings", a: "abc", b: "abc", same: true},
+		{name: "different strings", a: "abc", b: "abd", same: false},
+		{name: "equal ints", a: 42, b: 42, same: true},
+		{name: "different ints", a: 1, b: 2, same: false},
+		{name: "equal structs", a: point{1, 2}, b: point{1, 2}, same: true},
+		{name: "different structs", a: point{1, 2}, b: point{2, 1}, same: false},
+	}
+
+	hasher := DefaultHasher[any]()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := hasher(tc.a) == hasher(tc.b); got != tc.same {
+				t.Errorf("hash(%v) == hash(%v) is %v, want %v", tc.a, tc.b, got, tc.same)
+			}
+		})
+	}
+}
+
+func TestDefaultHasher_Stable(t *testing.T) {
+	// The hashes are part of the serialized form of the probabilistic structures and must not change.
+	if got, want := DefaultHasher[string]()("go-extend"), uint64(0x5f1a6e44a03f28df); got != want {
+		t.Errorf("hash(go-extend) = %#x, want %#x", got, want)
+	}
+}
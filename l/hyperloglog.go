@@ -0,0 +1,130 @@
+package l
+
+import (
+	"math"
+	"math/bits"
+)
+
+const (
+	hyperLogLogTag byte = 'H'
+
+	hyperLogLogMinPrecision = 4
+	hyperLogLogMaxPrecision = 16
+)
+
+// HyperLogLog estimates the number of distinct items in a stream using 2^precision bytes of memory.
+// The standard error of the estimate is about 1.04 / sqrt(2^precision), e.g. 0.8% for precision 14.
+type HyperLogLog[T any] struct {
+	precision uint8
+	registers []uint8
+	hasher    Hasher[T]
+}
+
+// NewHyperLogLog creates a HyperLogLog with the given precision, using DefaultHasher.
+// The precision is clamped to the range [4, 16].
+func NewHyperLogLog[T any](precision int) *HyperLogLog[T] {
+	return NewHyperLogLogWithHasher(precision, DefaultHasher[T]())
+}
+
+// NewHyperLogLogWithHasher creates a HyperLogLog like NewHyperLogLog that hashes the items with the given hasher.
+func NewHyperLogLogWithHasher[T any](precision int, hasher Hasher[T]) *HyperLogLog[T] {
+	precision = min(max(precision, hyperLogLogMinPrecision), hyperLogLogMaxPrecision)
+	return &HyperLogLog[T]{
+		precision: uint8(precision),
+		registers: make([]uint8, 1<<precision),
+		hasher:    hasher,
+	}
+}
+
+// Add adds items to the estimator.
+func (h *HyperLogLog[T]) Add(item ...T) {
+	if h.hasher == nil {
+		h.hasher = DefaultHasher[T]()
+	}
+	for _, i := range item {
+		hash := h.hasher(i)
+		index := hash >> (64 - h.precision)
+		// The marker bit bounds the rank when the remaining bits are all zero.
+		rank := uint8(bits.LeadingZeros64(hash<<h.precision|1<<(h.precision-1)) + 1)
+		if rank > h.registers[index] {
+			h.registers[index] = rank
+		}
+	}
+}
+
+// Count returns the estimated number of distinct items added to the estimator.
+func (h *HyperLogLog[T]) Count() uint64 {
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, register := range h.registers {
+		sum += 1 / float64(uint64(1)<<register)
+		if register == 0 {
+			zeros++
+		}
+	}
+
+	estimate := hyperLogLogAlpha(len(h.registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// Linear counting is more accurate for small cardinalities.
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(estimate))
+}
+
+// Precision returns the precision of the estimator.
+func (h *HyperLogLog[T]) Precision() int {
+	return int(h.precision)
+}
+
+// Clear removes all items from the estimator.
+func (h *HyperLogLog[T]) Clear() {
+	clear(h.registers)
+}
+
+// Merge adds all items of other to the estimator. It returns ErrIncompatible if the precisions differ.
+func (h *HyperLogLog[T]) Merge(other *HyperLogLog[T]) error {
+	if h.precision != other.precision {
+		return ErrIncompatible
+	}
+	for i, register := range other.registers {
+		h.registers[i] = max(h.registers[i], register)
+	}
+	return nil
+}
+
+// MarshalBinary encodes the estimator into a binary form. The hasher is not encoded.
+func (h *HyperLogLog[T]) MarshalBinary() ([]byte, error) {
+	data := marshalHeader(hyperLogLogTag, uint64(h.precision))
+	return append(data, h.registers...), nil
+}
+
+// UnmarshalBinary restores an estimator encoded by MarshalBinary. The hasher of the estimator is kept,
+// or DefaultHasher is used if the estimator has none.
+func (h *HyperLogLog[T]) UnmarshalBinary(data []byte) error {
+	params, data, err := unmarshalHeader(data, hyperLogLogTag, 1)
+	if err != nil {
+		return err
+	}
+	precision := params[0]
+	if precision < hyperLogLogMinPrecision || precision > hyperLogLogMaxPrecision || len(data) != 1<<precision {
+		return ErrInvalidData
+	}
+
+	h.precision = uint8(precision)
+	h.registers = append([]uint8(nil), data...)
+	return nil
+}
+
+func hyperLogLogAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
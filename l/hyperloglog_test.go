@@ -0,0 +1,78 @@
+package l
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestHyperLogLog_Count(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision int
+		distinct  int
+	}{
+		{name: "empty", precision: 14, distinct: 0},
+		{name: "small cardinality", precision: 14, distinct: 100},
+		{name: "large cardinality", precision: 14, distinct: 200000},
+		{name: "low precision", precision: 8, distinct: 10000},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHyperLogLog[int](tc.precision)
+			for i := 0; i < tc.distinct; i++ {
+				// Every item is added twice; duplicates must not be counted.
+				h.Add(i, i)
+			}
+
+			got := float64(h.Count())
+			tolerance := 4 * 1.04 / math.Sqrt(float64(int(1)<<tc.precision)) * float64(tc.distinct)
+			if math.Abs(got-float64(tc.distinct)) > max(tolerance, 1) {
+				t.Errorf("Count() = %v, want %d ± %.0f", got, tc.distinct, tolerance)
+			}
+		})
+	}
+}
+
+func TestHyperLogLog_Precision(t *testing.T) {
+	if got := NewHyperLogLog[int](1).Precision(); got != 4 {
+		t.Errorf("Precision() = %d, want 4", got)
+	}
+	if got := NewHyperLogLog[int](30).Precision(); got != 16 {
+		t.Errorf("Precision() = %d, want 16", got)
+	}
+}
+
+func TestHyperLogLog_MergeMarshal(t *testing.T) {
+	a := NewHyperLogLog[int](12)
+	b := NewHyperLogLog[int](12)
+	for i := 0; i < 5000; i++ {
+		a.Add(i)
+		b.Add(i + 2500)
+	}
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if err := a.Merge(NewHyperLogLog[int](10)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Merge() of different precision error = %v, want %v", err, ErrIncompatible)
+	}
+
+	data, _ := a.MarshalBinary()
+	var restored HyperLogLog[int]
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if got := restored.Count(); got != a.Count() || got < 7000 || got > 8000 {
+		t.Errorf("restored Count() = %d, want %d (about 7500)", got, a.Count())
+	}
+	if err := restored.UnmarshalBinary(data[:10]); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary() of truncated data error = %v, want %v", err, ErrInvalidData)
+	}
+
+	restored.Clear()
+	if restored.Count() != 0 {
+		t.Errorf("Count() after Clear = %d, want 0", restored.Count())
+	}
+}
@@ -0,0 +1,257 @@
+package l
+
+import "cmp"
+
+// IntervalTree stores half-open intervals [Lo, Hi) with a value each and finds the intervals that contain
+// a point or overlap a range. It is an AVL tree ordered by Lo and then Hi, where every node also knows the largest
+// Hi of its subtree, so Insert and Delete run in O(log n) and a query runs in O(log n + k) for k results.
+// There is at most one interval with the same bounds.
+type IntervalTree[T cmp.Ordered, V any] struct {
+	root   *intervalNode[T, V]
+	length int
+}
+
+// Interval is a half-open interval [Lo, Hi) with a value.
+type Interval[T cmp.Ordered, V any] struct {
+	Lo    T
+	Hi    T
+	Value V
+}
+
+type intervalNode[T cmp.Ordered, V any] struct {
+	avlLinks[*intervalNode[T, V]]
+	interval Interval[T, V]
+	maxHi    T
+}
+
+// NewIntervalTree creates an empty IntervalTree.
+//
+// Example usage:
+//
+//	t := NewIntervalTree[int, string]()
+//	t.Insert(9, 12, "standup")
+//	t.Insert(11, 13, "review")
+//	t.Stab(11) // [9, 12) standup, [11, 13) review
+//	t.Overlaps(12, 14) // true
+func NewIntervalTree[T cmp.Ordered, V any]() IntervalTree[T, V] {
+	return IntervalTree[T, V]{}
+}
+
+// Insert stores the interval [lo, hi) with the value, replacing the value if an interval with the same bounds
+// already exists. It returns true if the interval is new. It panics if the interval is empty, that is if hi <= lo.
+func (t *IntervalTree[T, V]) Insert(lo, hi T, value V) bool {
+	if hi <= lo {
+		panic("l: empty interval")
+	}
+
+	var added bool
+	t.root, added = t.insert(t.root, Interval[T, V]{Lo: lo, Hi: hi, Value: value})
+	if added {
+		t.length++
+	}
+	return added
+}
+
+// Get returns a pointer to the value of the interval [lo, hi). If there is no such interval, it returns nil.
+func (t *IntervalTree[T, V]) Get(lo, hi T) *V {
+	node := t.root
+	for node != nil {
+		switch c := compareInterval(lo, hi, node.interval); {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			node = node.right
+		default:
+			return &node.interval.Value
+		}
+	}
+	return nil
+}
+
+// Delete removes the interval [lo, hi). It returns true if the interval was in the tree.
+func (t *IntervalTree[T, V]) Delete(lo, hi T) bool {
+	var deleted bool
+	t.root, deleted = t.delete(t.root, lo, hi)
+	if deleted {
+		t.length--
+	}
+	return deleted
+}
+
+// Length returns the number of intervals in the tree.
+func (t *IntervalTree[T, V]) Length() int {
+	return t.length
+}
+
+// IsEmpty returns true if the tree has no intervals, false otherwise.
+func (t *IntervalTree[T, V]) IsEmpty() bool {
+	return t.root == nil
+}
+
+// Clear removes all intervals from the tree.
+func (t *IntervalTree[T, V]) Clear() {
+	t.root = nil
+	t.length = 0
+}
+
+// Stab returns a List with all intervals that contain the point, ordered by Lo and then Hi.
+func (t *IntervalTree[T, V]) Stab(point T) *List[Interval[T, V]] {
+	intervals := NewList[Interval[T, V]]()
+	t.overlapping(t.root, point, point, true, func(interval Interval[T, V]) bool {
+		intervals.Add(interval)
+		return true
+	})
+	return &intervals
+}
+
+// Overlapping returns a List with all intervals that overlap the range [lo, hi), ordered by Lo and then Hi.
+// Intervals that only touch the range, like [hi, x), do not overlap it, and an empty range, where hi <= lo,
+// overlaps no interval.
+func (t *IntervalTree[T, V]) Overlapping(lo, hi T) *List[Interval[T, V]] {
+	intervals := NewList[Interval[T, V]]()
+	t.overlapping(t.root, lo, hi, false, func(interval Interval[T, V]) bool {
+		intervals.Add(interval)
+		return true
+	})
+	return &intervals
+}
+
+// Overlaps returns true if at least one interval overlaps the range [lo, hi). It returns false for an empty range,
+// where hi <= lo.
+func (t *IntervalTree[T, V]) Overlaps(lo, hi T) bool {
+	found := false
+	t.overlapping(t.root, lo, hi, false, func(Interval[T, V]) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// ForEach calls f for every interval, ordered by Lo and then Hi.
+func (t *IntervalTree[T, V]) ForEach(f func(interval Interval[T, V])) {
+	intervalAscend(t.root, func(interval Interval[T, V]) bool {
+		f(interval)
+		return true
+	})
+}
+
+// All returns a function that calls yield with every interval, ordered by Lo and then Hi,
+// until yield returns false.
+func (t *IntervalTree[T, V]) All() func(yield func(Interval[T, V]) bool) {
+	return func(yield func(Interval[T, V]) bool) {
+		intervalAscend(t.root, yield)
+	}
+}
+
+// Merged returns a List with the union of all intervals, where every group of overlapping intervals is merged
+// into one. The value of a merged interval is a List with the values of its parts, ordered by Lo and then Hi.
+// Intervals that only touch each other, like [1, 3) and [3, 5), are not merged.
+func (t *IntervalTree[T, V]) Merged() *List[Interval[T, List[V]]] {
+	merged := NewList[Interval[T, List[V]]]()
+	t.ForEach(func(interval Interval[T, V]) {
+		if n := merged.Length(); n > 0 {
+			if last := merged.Get(n - 1); interval.Lo < last.Hi {
+				last.Hi = max(last.Hi, interval.Hi)
+				last.Value.Add(interval.Value)
+				return
+			}
+		}
+		merged.Add(Interval[T, List[V]]{Lo: interval.Lo, Hi: interval.Hi, Value: NewList(interval.Value)})
+	})
+	return &merged
+}
+
+func (t *IntervalTree[T, V]) insert(node *intervalNode[T, V], interval Interval[T, V]) (*intervalNode[T, V], bool) {
+	if node == nil {
+		return &intervalNode[T, V]{avlLinks: avlLinks[*intervalNode[T, V]]{height: 1}, interval: interval, maxHi: interval.Hi}, true
+	}
+
+	var added bool
+	switch c := compareInterval(interval.Lo, interval.Hi, node.interval); {
+	case c < 0:
+		node.left, added = t.insert(node.left, interval)
+	case c > 0:
+		node.right, added = t.insert(node.right, interval)
+	default:
+		node.interval.Value = interval.Value
+		return node, false
+	}
+	return avlBalance(node), added
+}
+
+func (t *IntervalTree[T, V]) delete(node *intervalNode[T, V], lo, hi T) (*intervalNode[T, V], bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	var deleted bool
+	switch c := compareInterval(lo, hi, node.interval); {
+	case c < 0:
+		node.left, deleted = t.delete(node.left, lo, hi)
+	case c > 0:
+		node.right, deleted = t.delete(node.right, lo, hi)
+	default:
+		if node.left == nil {
+			return node.right, true
+		}
+		if node.right == nil {
+			return node.left, true
+		}
+		var successor *intervalNode[T, V]
+		node.right, successor = avlRemoveMin(node.right)
+		successor.left, successor.right = node.left, node.right
+		return avlBalance(successor), true
+	}
+	return avlBalance(node), deleted
+}
+
+// overlapping visits the intervals of the subtree that overlap [lo, hi) in ascending order. If point is true,
+// it visits the intervals that contain lo instead. It returns false if the iteration was stopped.
+func (t *IntervalTree[T, V]) overlapping(node *intervalNode[T, V], lo, hi T, point bool, f func(Interval[T, V]) bool) bool {
+	// No interval in the subtree ends after lo, or the range is empty.
+	if node == nil || node.maxHi <= lo || !point && hi <= lo {
+		return true
+	}
+	if !t.overlapping(node.left, lo, hi, point, f) {
+		return false
+	}
+
+	// The intervals of the node and its right subtree start at or after node.interval.Lo.
+	if point && node.interval.Lo > lo || !point && node.interval.Lo >= hi {
+		return true
+	}
+	if lo < node.interval.Hi && !f(node.interval) {
+		return false
+	}
+	return t.overlapping(node.right, lo, hi, point, f)
+}
+
+// compareInterval orders the interval [lo, hi) relative to the other interval by Lo and then Hi.
+func compareInterval[T cmp.Ordered, V any](lo, hi T, other Interval[T, V]) int {
+	if c := cmp.Compare(lo, other.Lo); c != 0 {
+		return c
+	}
+	return cmp.Compare(hi, other.Hi)
+}
+
+// intervalAscend visits the intervals of the subtree in ascending order. It returns false if the iteration was stopped.
+func intervalAscend[T cmp.Ordered, V any](node *intervalNode[T, V], f func(Interval[T, V]) bool) bool {
+	if node == nil {
+		return true
+	}
+	return intervalAscend(node.left, f) && f(node.interval) && intervalAscend(node.right, f)
+}
+
+func (n *intervalNode[T, V]) links() *avlLinks[*intervalNode[T, V]] {
+	return &n.avlLinks
+}
+
+func (n *intervalNode[T, V]) update() {
+	n.maxHi = n.interval.Hi
+	if n.left != nil {
+		n.maxHi = max(n.maxHi, n.left.maxHi)
+	}
+	if n.right != nil {
+		n.maxHi = max(n.maxHi, n.right.maxHi)
+	}
+}
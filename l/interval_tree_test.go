@@ -0,0 +1,150 @@
+package l
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func intervalBounds[V any](intervals *List[Interval[int, V]]) [][2]int {
+	bounds := [][2]int{}
+	for _, interval := range intervals.Slice() {
+		bounds = append(bounds, [2]int{interval.Lo, interval.Hi})
+	}
+	return bounds
+}
+
+func TestIntervalTree_Queries(t *testing.T) {
+	tree := NewIntervalTree[int, string]()
+	tree.Insert(9, 12, "standup")
+	tree.Insert(11, 13, "review")
+	tree.Insert(14, 16, "lunch")
+	tree.Insert(1, 20, "focus")
+	tree.Insert(16, 17, "call")
+
+	tests := []struct {
+		name string
+		got  *List[Interval[int, string]]
+		want [][2]int
+	}{
+		{name: "stab inside two", got: tree.Stab(11), want: [][2]int{{1, 20}, {9, 12}, {11, 13}}},
+		{name: "stab at end is excluded", got: tree.Stab(16), want: [][2]int{{1, 20}, {16, 17}}},
+		{name: "stab outside", got: tree.Stab(20), want: [][2]int{}},
+		{name: "overlap", got: tree.Overlapping(12, 14), want: [][2]int{{1, 20}, {11, 13}}},
+		{name: "touching is not overlapping", got: tree.Overlapping(20, 30), want: [][2]int{}},
+		{name: "empty range overlaps nothing", got: tree.Overlapping(11, 11), want: [][2]int{}},
+		{name: "reversed range overlaps nothing", got: tree.Overlapping(13, 11), want: [][2]int{}},
+		{name: "overlap all", got: tree.Overlapping(0, 100), want: [][2]int{{1, 20}, {9, 12}, {11, 13}, {14, 16}, {16, 17}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := intervalBounds(tc.got); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("intervals = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if !tree.Overlaps(19, 25) || tree.Overlaps(-5, 1) || tree.Overlaps(11, 11) {
+		t.Errorf("Overlaps() returned a wrong result")
+	}
+	if value := tree.Get(14, 16); value == nil || *value != "lunch" {
+		t.Errorf("Get(14, 16) = %v, want lunch", value)
+	}
+	if tree.Insert(14, 16, "long lunch") || *tree.Get(14, 16) != "long lunch" || tree.Length() != 5 {
+		t.Errorf("Insert() of existing bounds did not replace the value")
+	}
+}
+
+func TestIntervalTree_Merged(t *testing.T) {
+	tests := []struct {
+		name      string
+		intervals [][2]int
+		want      [][2]int
+		values    [][]int
+	}{
+		{name: "empty", want: [][2]int{}, values: [][]int{}},
+		{name: "disjoint and touching", intervals: [][2]int{{5, 7}, {1, 3}, {3, 5}}, want: [][2]int{{1, 3}, {3, 5}, {5, 7}}, values: [][]int{{1}, {3}, {5}}},
+		{name: "overlapping chain", intervals: [][2]int{{1, 4}, {3, 6}, {5, 8}, {10, 12}}, want: [][2]int{{1, 8}, {10, 12}}, values: [][]int{{1, 3, 5}, {10}}},
+		{name: "nested", intervals: [][2]int{{1, 10}, {2, 3}, {4, 5}}, want: [][2]int{{1, 10}}, values: [][]int{{1, 2, 4}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := NewIntervalTree[int, int]()
+			for _, interval := range tc.intervals {
+				tree.Insert(interval[0], interval[1], interval[0])
+			}
+
+			merged := tree.Merged()
+			if got := intervalBounds(merged); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Merged() = %v, want %v", got, tc.want)
+			}
+			values := [][]int{}
+			for _, interval := range merged.Slice() {
+				values = append(values, interval.Value.Slice())
+			}
+			if !reflect.DeepEqual(values, tc.values) {
+				t.Errorf("Merged() values = %v, want %v", values, tc.values)
+			}
+		})
+	}
+}
+
+func TestIntervalTree_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	tree := NewIntervalTree[int, int]()
+	intervals := map[[2]int]bool{}
+
+	for i := 0; i < 2000; i++ {
+		lo := rnd.Intn(1000)
+		bounds := [2]int{lo, lo + 1 + rnd.Intn(50)}
+		if rnd.Intn(3) == 0 {
+			if tree.Delete(bounds[0], bounds[1]) != intervals[bounds] {
+				t.Fatalf("Delete(%v) disagrees with the reference", bounds)
+			}
+			delete(intervals, bounds)
+		} else {
+			tree.Insert(bounds[0], bounds[1], i)
+			intervals[bounds] = true
+		}
+	}
+	if tree.Length() != len(intervals) {
+		t.Fatalf("Length() = %d, want %d", tree.Length(), len(intervals))
+	}
+
+	for i := 0; i < 200; i++ {
+		lo := rnd.Intn(1100)
+		hi := lo + 1 + rnd.Intn(30)
+		stab, overlap := 0, 0
+		for bounds := range intervals {
+			if bounds[0] <= lo && lo < bounds[1] {
+				stab++
+			}
+			if bounds[0] < hi && lo < bounds[1] {
+				overlap++
+			}
+		}
+		if got := tree.Stab(lo).Length(); got != stab {
+			t.Errorf("Stab(%d) found %d intervals, want %d", lo, got, stab)
+		}
+		if got := tree.Overlapping(lo, hi).Length(); got != overlap {
+			t.Errorf("Overlapping(%d, %d) found %d intervals, want %d", lo, hi, got, overlap)
+		}
+	}
+
+	tree.Clear()
+	if !tree.IsEmpty() || tree.Length() != 0 {
+		t.Errorf("tree not empty after Clear")
+	}
+}
+
+func TestIntervalTree_EmptyInterval(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Insert() of an empty interval did not panic")
+		}
+	}()
+	tree := NewIntervalTree[int, int]()
+	tree.Insert(3, 3, 0)
+}
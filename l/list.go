@@ -3,11 +3,41 @@ package l
 import (
 	"reflect"
 	"sync"
+	"sync/atomic"
 )
 
 // List represents a generic list data structure.
 type List[T any] struct {
 	items []T
+	// modCount counts the structural modifications, which change the length of the list.
+	// Views created by SubList use it to detect that they are no longer valid.
+	modCount int
+	// iterating counts the running iterations. It is accessed atomically, because the callbacks of
+	// ParallelForEach run in their own goroutines.
+	iterating int32
+	// growth computes the new capacity when the items do not fit. If it is nil, append grows the items.
+	growth GrowthPolicy
+}
+
+// ConcurrentModificationError is the value of the panic raised when a List is structurally modified while it is
+// iterated, or when a SubList is used after its List was structurally modified. It can be recovered with r.Try.
+//
+// Example usage:
+//
+//	r.Try(func() {
+//	    list.ForEach(func(index int, item int) {
+//	        list.Remove(index)
+//	    })
+//	}).Catch(func(err any) {
+//	    // err is a *ConcurrentModificationError with Operation "Remove"
+//	})
+type ConcurrentModificationError struct {
+	// Operation is the method that detected the modification.
+	Operation string
+}
+
+func (e *ConcurrentModificationError) Error() string {
+	return "l: concurrent modification of the List detected by " + e.Operation
 }
 
 // NewList creates a new instance of the List struct with an empty items slice.
@@ -22,6 +52,8 @@ func NewList[T any](items ...T) List[T] {
 // l.Add(4, 5, 6) -> l.items will be []int{1, 2, 3, 4, 5, 6}
 // l.Add([]int{7, 8}...) -> l.items will be []int{1, 2, 3, 4, 5, 6, 7, 8}
 func (l *List[T]) Add(item ...T) {
+	l.modify("Add")
+	l.reserve(len(item))
 	l.items = append(l.items, item...)
 }
 
@@ -66,6 +98,8 @@ func (l *List[T]) Slice() []T {
 // Note: The index parameter should be a non-negative integer. If the index is greater than the length of the slice,
 // the item will be appended to the end of the list.
 func (l *List[T]) Insert(index int, item T) {
+	l.modify("Insert")
+	l.reserve(1)
 	l.items = append(l.items[:index], append([]T{item}, l.items[index:]...)...)
 }
 
@@ -99,28 +133,57 @@ func (l *List[T]) Length() int {
 // Item at index 0: 1
 // Item at index 1: 2
 // Item at index 2: 3
+//
+// Adding or removing items of the list from f panics with a ConcurrentModificationError.
 func (l *List[T]) ForEach(f func(index int, item T)) {
+	defer l.iterate()()
 	for index, item := range l.items {
 		f(index, item)
 	}
 }
 
+// All returns a function that calls yield with every item of the list in order, until yield returns false.
+// Adding or removing items of the list from yield panics with a ConcurrentModificationError.
+func (l *List[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		defer l.iterate()()
+		for _, item := range l.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
 // ParallelForEach takes a function `f` as a parameter which is executed for each item in the list concurrently.
 // The function `f` receives two parameters: an index (int) and an item (T) from the list.
 // The `sync.WaitGroup` `wg` is used to ensure all goroutines finish execution before returning.
 // For each item in the list, a goroutine is created which calls the function `f` with the index and item as arguments.
 // At the end of each goroutine, `wg.Done()` is called to indicate that the goroutine has finished execution.
 // After creating all goroutines, `wg.Wait()` is called to wait for all goroutines to complete execution.
+// If `f` panics, for example with a ConcurrentModificationError because it adds or removes items of the list,
+// the first panic is raised again in the calling goroutine after all goroutines have finished.
 func (l *List[T]) ParallelForEach(f func(index int, item T)) {
+	defer l.iterate()()
 	wg := sync.WaitGroup{}
 	wg.Add(len(l.items))
+	var once sync.Once
+	var recovered any
 	for index, item := range l.items {
 		go func(index int, item T) {
 			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() { recovered = r })
+				}
+			}()
 			f(index, item)
 		}(index, item)
 	}
 	wg.Wait()
+	if recovered != nil {
+		panic(recovered)
+	}
 }
 
 // Remove removes an item from the list at the specified index.
@@ -136,6 +199,7 @@ func (l *List[T]) Remove(index int) {
 	if index < 0 || index >= len(l.items) {
 		return
 	}
+	l.modify("Remove")
 	l.items = append(l.items[:index], l.items[index+1:]...)
 }
 
@@ -185,7 +249,9 @@ func (l *List[T]) Contains(item T) bool {
 // l := List[Person]{items: []Person{p1, p2, p3}}
 // l.Clear() -> l.items will be []Person{}
 // Note: This method does not deallocate or free up any resources held by the items in the list.
+// It drops the backing array; Reset keeps it for new items.
 func (l *List[T]) Clear() {
+	l.modify("Clear")
 	l.items = []T{}
 }
 
@@ -235,3 +301,66 @@ func (l *List[T]) FindAll(findFunc func(T) bool) *List[T] {
 	}
 	return &list
 }
+
+// RemoveIf removes all items for which the predicate returns true, keeping the order of the other items.
+// It filters the list in place and returns the number of removed items.
+//
+// Example usage:
+//
+//	l := NewList(1, 2, 3, 4, 5)
+//	l.RemoveIf(func(item int) bool { return item%2 == 0 }) // 2, l is [1, 3, 5]
+func (l *List[T]) RemoveIf(predicate func(T) bool) int {
+	return l.filter("RemoveIf", predicate, false)
+}
+
+// RetainIf keeps only the items for which the predicate returns true, keeping their order.
+// It filters the list in place and returns the number of removed items.
+func (l *List[T]) RetainIf(predicate func(T) bool) int {
+	return l.filter("RetainIf", predicate, true)
+}
+
+// filter keeps the items for which the predicate returns keep. Modifying the list from the predicate panics,
+// just like during ForEach. If the predicate panics, the items it has not filtered yet are kept.
+func (l *List[T]) filter(operation string, predicate func(T) bool, keep bool) int {
+	l.checkIterating(operation)
+	length := len(l.items)
+	kept, next := 0, 0
+	defer func() {
+		kept += copy(l.items[kept:], l.items[next:])
+		clear(l.items[kept:])
+		l.items = l.items[:kept]
+		if kept < length {
+			l.modCount++
+		}
+	}()
+
+	defer l.iterate()()
+	for ; next < length; next++ {
+		if item := l.items[next]; predicate(item) == keep {
+			l.items[kept] = item
+			kept++
+		}
+	}
+	return length - kept
+}
+
+// iterate marks the start of an iteration and returns the function that marks its end.
+func (l *List[T]) iterate() func() {
+	atomic.AddInt32(&l.iterating, 1)
+	return func() {
+		atomic.AddInt32(&l.iterating, -1)
+	}
+}
+
+// modify records a structural modification by the operation. It panics with a ConcurrentModificationError if the
+// list is being iterated.
+func (l *List[T]) modify(operation string) {
+	l.checkIterating(operation)
+	l.modCount++
+}
+
+func (l *List[T]) checkIterating(operation string) {
+	if atomic.LoadInt32(&l.iterating) > 0 {
+		panic(&ConcurrentModificationError{Operation: operation})
+	}
+}
@@ -2,6 +2,7 @@ package l
 
 import (
 	"go-extend/p"
+	"go-extend/r"
 	"reflect"
 	"sync"
 	"testing"
@@ -409,37 +410,37 @@ func TestList_Contains(t *testing.T) {
 		},
 		{
 			name:     "single item is present",
-			list:     List[int]{[]int{5}},
+			list:     List[int]{items: []int{5}},
 			item:     5,
 			expected: true,
 		},
 		{
 			name:     "single item is absent",
-			list:     List[int]{[]int{3}},
+			list:     List[int]{items: []int{3}},
 			item:     5,
 			expected: false,
 		},
 		{
 			name:     "multiple items is present start",
-			list:     List[int]{[]int{5, 6, 7}},
+			list:     List[int]{items: []int{5, 6, 7}},
 			item:     5,
 			expected: true,
 		},
 		{
 			name:     "multiple items is present middle",
-			list:     List[int]{[]int{5, 6, 7}},
+			list:     List[int]{items: []int{5, 6, 7}},
 			item:     6,
 			expected: true,
 		},
 		{
 			name:     "multiple items is present end",
-			list:     List[int]{[]int{5, 6, 7}},
+			list:     List[int]{items: []int{5, 6, 7}},
 			item:     7,
 			expected: true,
 		},
 		{
 			name:     "multiple items is absent",
-			list:     List[int]{[]int{5, 6, 7}},
+			list:     List[int]{items: []int{5, 6, 7}},
 			item:     8,
 			expected: false,
 		},
@@ -646,3 +647,182 @@ func TestList_FindAll(t *testing.T) {
 		})
 	}
 }
+
+func TestList_ConcurrentModification(t *testing.T) {
+	iterations := map[string]func(list *List[int], f func(index int)){
+		"ForEach": func(list *List[int], f func(index int)) {
+			list.ForEach(func(index int, _ int) { f(index) })
+		},
+		"ParallelForEach": func(list *List[int], f func(index int)) {
+			list.ParallelForEach(func(index int, _ int) { f(index) })
+		},
+		"All": func(list *List[int], f func(index int)) {
+			index := 0
+			list.All()(func(int) bool {
+				f(index)
+				index++
+				return true
+			})
+		},
+		"SubList.ForEach": func(list *List[int], f func(index int)) {
+			view := list.SubList(0, list.Length())
+			view.ForEach(func(index int, _ int) { f(index) })
+		},
+		"SubList.All": func(list *List[int], f func(index int)) {
+			view := list.SubList(0, list.Length())
+			index := 0
+			view.All()(func(int) bool {
+				f(index)
+				index++
+				return true
+			})
+		},
+		"Permutations": func(list *List[int], f func(index int)) {
+			generatorIndexes(Permutations(list), f)
+		},
+		"Combinations": func(list *List[int], f func(index int)) {
+			generatorIndexes(Combinations(list, 2), f)
+		},
+		"CartesianProduct": func(list *List[int], f func(index int)) {
+			other := NewList(1)
+			generatorIndexes(CartesianProduct(&other, list), f)
+		},
+		"Partitions": func(list *List[int], f func(index int)) {
+			generatorIndexes(Partitions(list), f)
+		},
+		"RemoveIf": func(list *List[int], f func(index int)) {
+			index := 0
+			list.RemoveIf(func(int) bool {
+				f(index)
+				index++
+				return false
+			})
+		},
+	}
+	modifications := map[string]func(list *List[int], index int){
+		"Add":    func(list *List[int], _ int) { list.Add(0) },
+		"Insert": func(list *List[int], index int) { list.Insert(index, 0) },
+		"Remove": func(list *List[int], index int) { list.Remove(index) },
+		"Clear":  func(list *List[int], _ int) { list.Clear() },
+		"RetainIf": func(list *List[int], _ int) {
+			list.RetainIf(func(int) bool { return false })
+		},
+	}
+
+	for iterationName, iterate := range iterations {
+		for operation, modify := range modifications {
+			t.Run(iterationName+" "+operation, func(t *testing.T) {
+				list := NewList(1, 2, 3)
+				var caught any
+				r.Try(func() {
+					iterate(&list, func(index int) { modify(&list, index) })
+				}).Catch(func(err any) {
+					caught = err
+				})
+
+				err, ok := caught.(*ConcurrentModificationError)
+				if !ok {
+					t.Fatalf("recovered %v, want a *ConcurrentModificationError", caught)
+				}
+				if err.Operation != operation {
+					t.Errorf("Operation = %q, want %q", err.Operation, operation)
+				}
+				if got := list.Slice(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+					t.Errorf("list modified to %v", got)
+				}
+
+				// The list can be modified again once the iteration is over.
+				list.Add(4)
+				if list.Length() != 4 {
+					t.Errorf("Length() = %d after Add, want 4", list.Length())
+				}
+			})
+		}
+	}
+}
+
+// generatorIndexes calls f with the index of every result of the generator.
+func generatorIndexes[R any](generator func(yield func(R) bool), f func(index int)) {
+	index := 0
+	generator(func(R) bool {
+		f(index)
+		index++
+		return true
+	})
+}
+
+func TestList_ConcurrentModification_Nested(t *testing.T) {
+	list := NewList(1, 2)
+	sum := 0
+	list.ForEach(func(_ int, a int) {
+		list.ForEach(func(_ int, b int) {
+			sum += a * b
+		})
+	})
+	if sum != 9 {
+		t.Errorf("sum = %d, want 9", sum)
+	}
+	list.Add(3)
+
+	expected := "l: concurrent modification of the List detected by Add"
+	if err := (&ConcurrentModificationError{Operation: "Add"}); err.Error() != expected {
+		t.Errorf("Error() = %q, want %q", err.Error(), expected)
+	}
+}
+
+func TestList_RemoveIf(t *testing.T) {
+	isEven := func(item int) bool { return item%2 == 0 }
+
+	tests := []struct {
+		name        string
+		items       []int
+		retain      bool
+		wantRemoved int
+		want        []int
+	}{
+		{name: "RemoveIf", items: []int{1, 2, 3, 4, 5}, wantRemoved: 2, want: []int{1, 3, 5}},
+		{name: "RemoveIf nothing matches", items: []int{1, 3}, wantRemoved: 0, want: []int{1, 3}},
+		{name: "RemoveIf everything matches", items: []int{2, 4}, wantRemoved: 2, want: []int{}},
+		{name: "RemoveIf empty", items: []int{}, wantRemoved: 0, want: []int{}},
+		{name: "RetainIf", items: []int{1, 2, 3, 4, 5}, retain: true, wantRemoved: 3, want: []int{2, 4}},
+		{name: "RetainIf everything matches", items: []int{2, 4}, retain: true, wantRemoved: 0, want: []int{2, 4}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			list := NewList(tc.items...)
+			var removed int
+			if tc.retain {
+				removed = list.RetainIf(isEven)
+			} else {
+				removed = list.RemoveIf(isEven)
+			}
+			if removed != tc.wantRemoved {
+				t.Errorf("removed = %d, want %d", removed, tc.wantRemoved)
+			}
+			if got := list.Slice(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Slice() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestList_RemoveIf_Panic(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5)
+	view := list.SubList(0, 1)
+
+	r.Try(func() {
+		list.RemoveIf(func(item int) bool {
+			if item == 4 {
+				panic("stop")
+			}
+			return item%2 == 1
+		})
+	}).Catch(func(any) {})
+
+	// The items before the panic are filtered, the others are kept.
+	if got := list.Slice(); !reflect.DeepEqual(got, []int{2, 4, 5}) {
+		t.Errorf("Slice() = %v, want [2 4 5]", got)
+	}
+	assertPanics(t, "SubList after RemoveIf", func() { view.Length() })
+}
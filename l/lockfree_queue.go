@@ -0,0 +1,174 @@
+package l
+
+import (
+	"sync/atomic"
+)
+
+// cacheLineSize is used to pad the hot fields of the lock-free queues so that producers and consumers
+// do not invalidate each other's cache lines.
+const cacheLineSize = 64
+
+// MPMCQueue is a bounded lock-free FIFO queue for any number of producers and consumers.
+// It is a ring buffer in which every cell carries a sequence number that tells producers and consumers
+// whether the cell is free or filled for their position (D. Vyukov's bounded MPMC queue).
+// Push and Pop never block and never take a lock.
+type MPMCQueue[T any] struct {
+	_     [cacheLineSize]byte
+	head  atomic.Uint64
+	_     [cacheLineSize - 8]byte
+	tail  atomic.Uint64
+	_     [cacheLineSize - 8]byte
+	mask  uint64
+	cells []mpmcCell[T]
+}
+
+type mpmcCell[T any] struct {
+	seq  atomic.Uint64
+	item T
+}
+
+// NewMPMCQueue creates an empty MPMCQueue that holds at least the given number of items.
+// The capacity is rounded up to the next power of two.
+func NewMPMCQueue[T any](capacity int) *MPMCQueue[T] {
+	size := uint64(2)
+	for size < uint64(capacity) {
+		size <<= 1
+	}
+
+	q := &MPMCQueue[T]{
+		mask:  size - 1,
+		cells: make([]mpmcCell[T], size),
+	}
+	for i := range q.cells {
+		q.cells[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// Push appends the given item to the end of the queue. It returns false if the queue is full.
+func (q *MPMCQueue[T]) Push(item T) bool {
+	pos := q.tail.Load()
+	for {
+		cell := &q.cells[pos&q.mask]
+		diff := int64(cell.seq.Load()) - int64(pos)
+		switch {
+		case diff == 0:
+			if q.tail.CompareAndSwap(pos, pos+1) {
+				cell.item = item
+				cell.seq.Store(pos + 1)
+				return true
+			}
+			pos = q.tail.Load()
+		case diff < 0:
+			// The cell still holds the item from the previous lap.
+			return false
+		default:
+			pos = q.tail.Load()
+		}
+	}
+}
+
+// Pop removes and returns the first item in the queue. If the queue is empty, it returns nil.
+func (q *MPMCQueue[T]) Pop() *T {
+	pos := q.head.Load()
+	for {
+		cell := &q.cells[pos&q.mask]
+		diff := int64(cell.seq.Load()) - int64(pos+1)
+		switch {
+		case diff == 0:
+			if q.head.CompareAndSwap(pos, pos+1) {
+				item := cell.item
+				var zero T
+				cell.item = zero
+				cell.seq.Store(pos + q.mask + 1)
+				return &item
+			}
+			pos = q.head.Load()
+		case diff < 0:
+			// The cell has not been filled for this lap yet.
+			return nil
+		default:
+			pos = q.head.Load()
+		}
+	}
+}
+
+// Length returns the number of items in the queue.
+// The result is a snapshot and may be out of date as soon as it is returned.
+func (q *MPMCQueue[T]) Length() int {
+	head := q.head.Load()
+	tail := q.tail.Load()
+	if tail < head {
+		return 0
+	}
+	return int(tail - head)
+}
+
+// Capacity returns the maximum number of items the queue can hold.
+func (q *MPMCQueue[T]) Capacity() int {
+	return len(q.cells)
+}
+
+// MPSCQueue is an unbounded lock-free FIFO queue for any number of producers and a single consumer.
+// Producers link new nodes with a single atomic swap (D. Vyukov's MPSC node-based queue), so Push never fails and
+// never blocks. Pop and Peek must only be called from one goroutine at a time.
+type MPSCQueue[T any] struct {
+	_      [cacheLineSize]byte
+	head   atomic.Pointer[mpscNode[T]]
+	_      [cacheLineSize - 8]byte
+	length atomic.Int64
+	_      [cacheLineSize - 8]byte
+	tail   *mpscNode[T]
+}
+
+type mpscNode[T any] struct {
+	next atomic.Pointer[mpscNode[T]]
+	item T
+}
+
+// NewMPSCQueue creates an empty MPSCQueue.
+func NewMPSCQueue[T any]() *MPSCQueue[T] {
+	stub := &mpscNode[T]{}
+	q := &MPSCQueue[T]{tail: stub}
+	q.head.Store(stub)
+	return q
+}
+
+// Push appends the given item to the end of the queue. It is safe to call from multiple goroutines.
+func (q *MPSCQueue[T]) Push(item T) {
+	node := &mpscNode[T]{item: item}
+	q.length.Add(1)
+	prev := q.head.Swap(node)
+	prev.next.Store(node)
+}
+
+// Pop removes and returns the first item in the queue. If the queue is empty, it returns nil.
+// Pop may briefly return nil while a concurrent Push is linking its item.
+func (q *MPSCQueue[T]) Pop() *T {
+	next := q.tail.next.Load()
+	if next == nil {
+		return nil
+	}
+
+	item := next.item
+	var zero T
+	next.item = zero
+	q.tail = next
+	q.length.Add(-1)
+	return &item
+}
+
+// Peek returns a pointer to the first item in the queue. If the queue is empty, it returns nil.
+func (q *MPSCQueue[T]) Peek() *T {
+	next := q.tail.next.Load()
+	if next == nil {
+		return nil
+	}
+	return &next.item
+}
+
+// Length returns the number of items in the queue.
+// The result is a snapshot and may be out of date as soon as it is returned.
+func (q *MPSCQueue[T]) Length() int {
+	return int(q.length.Load())
+}
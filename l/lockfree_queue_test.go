@@ -0,0 +1,285 @@
+package l
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestMPMCQueue_PushPop(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		input    []int
+		accepted []int
+	}{
+		{
+			name:     "empty queue",
+			capacity: 4,
+			input:    []int{},
+			accepted: []int{},
+		},
+		{
+			name:     "within capacity",
+			capacity: 4,
+			input:    []int{1, 2, 3},
+			accepted: []int{1, 2, 3},
+		},
+		{
+			name:     "full queue rejects",
+			capacity: 4,
+			input:    []int{1, 2, 3, 4, 5, 6},
+			accepted: []int{1, 2, 3, 4},
+		},
+		{
+			name:     "capacity rounded up",
+			capacity: 3,
+			input:    []int{1, 2, 3, 4, 5},
+			accepted: []int{1, 2, 3, 4},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewMPMCQueue[int](tc.capacity)
+			for i, item := range tc.input {
+				if got, want := q.Push(item), i < len(tc.accepted); got != want {
+					t.Errorf("Push(%d) = %v, want %v", item, got, want)
+				}
+			}
+			if q.Length() != len(tc.accepted) {
+				t.Errorf("Length() = %d, want %d", q.Length(), len(tc.accepted))
+			}
+
+			got := []int{}
+			for item := q.Pop(); item != nil; item = q.Pop() {
+				got = append(got, *item)
+			}
+			if !reflect.DeepEqual(got, tc.accepted) {
+				t.Errorf("Pop() sequence = %v, want %v", got, tc.accepted)
+			}
+		})
+	}
+}
+
+func TestMPMCQueue_WrapAround(t *testing.T) {
+	q := NewMPMCQueue[int](2)
+	for i := 0; i < 10; i++ {
+		if !q.Push(i) {
+			t.Fatalf("Push(%d) = false", i)
+		}
+		if got := q.Pop(); got == nil || *got != i {
+			t.Fatalf("Pop() = %v, want %d", got, i)
+		}
+	}
+	if q.Capacity() != 2 {
+		t.Errorf("Capacity() = %d, want 2", q.Capacity())
+	}
+}
+
+func TestMPMCQueue_Concurrent(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 10000
+
+	q := NewMPMCQueue[int](64)
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				for !q.Push(p*perProducer + i) {
+					runtime.Gosched()
+				}
+			}
+		}(p)
+	}
+
+	seen := make([]int, producers*perProducer)
+	var mu sync.Mutex
+	var consumed sync.WaitGroup
+	remaining := producers * perProducer
+	for c := 0; c < consumers; c++ {
+		consumed.Add(1)
+		go func() {
+			defer consumed.Done()
+			for {
+				mu.Lock()
+				if remaining == 0 {
+					mu.Unlock()
+					return
+				}
+				mu.Unlock()
+
+				item := q.Pop()
+				if item == nil {
+					runtime.Gosched()
+					continue
+				}
+				mu.Lock()
+				seen[*item]++
+				remaining--
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	consumed.Wait()
+	for item, count := range seen {
+		if count != 1 {
+			t.Fatalf("item %d consumed %d times", item, count)
+		}
+	}
+}
+
+func TestMPSCQueue_PushPop(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+	}{
+		{
+			name:  "empty queue",
+			input: []string{},
+		},
+		{
+			name:  "single item",
+			input: []string{"a"},
+		},
+		{
+			name:  "multiple items",
+			input: []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewMPSCQueue[string]()
+			for _, item := range tc.input {
+				q.Push(item)
+			}
+			if q.Length() != len(tc.input) {
+				t.Errorf("Length() = %d, want %d", q.Length(), len(tc.input))
+			}
+			if len(tc.input) > 0 {
+				if got := q.Peek(); got == nil || *got != tc.input[0] {
+					t.Errorf("Peek() = %v, want %v", got, tc.input[0])
+				}
+			}
+
+			got := []string{}
+			for item := q.Pop(); item != nil; item = q.Pop() {
+				got = append(got, *item)
+			}
+			if !reflect.DeepEqual(got, tc.input) {
+				t.Errorf("Pop() sequence = %v, want %v", got, tc.input)
+			}
+			if q.Peek() != nil || q.Length() != 0 {
+				t.Errorf("queue not empty after draining")
+			}
+		})
+	}
+}
+
+func TestMPSCQueue_Concurrent(t *testing.T) {
+	const producers, perProducer = 8, 10000
+
+	q := NewMPSCQueue[int]()
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				q.Push(p*perProducer + i)
+			}
+		}(p)
+	}
+
+	last := make([]int, producers)
+	for i := range last {
+		last[i] = -1
+	}
+	for received := 0; received < producers*perProducer; {
+		item := q.Pop()
+		if item == nil {
+			runtime.Gosched()
+			continue
+		}
+		// Items of a single producer must keep their order.
+		p, i := *item/perProducer, *item%perProducer
+		if i <= last[p] {
+			t.Fatalf("producer %d: item %d received after %d", p, i, last[p])
+		}
+		last[p] = i
+		received++
+	}
+	wg.Wait()
+}
+
+func BenchmarkMPMCQueue(b *testing.B) {
+	q := NewMPMCQueue[int](1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for !q.Push(1) {
+				runtime.Gosched()
+			}
+			for q.Pop() == nil {
+				runtime.Gosched()
+			}
+		}
+	})
+}
+
+func BenchmarkMPSCQueue(b *testing.B) {
+	q := NewMPSCQueue[int]()
+	done := make(chan struct{})
+	go func() {
+		for received := 0; received < b.N; {
+			if q.Pop() != nil {
+				received++
+			} else {
+				runtime.Gosched()
+			}
+		}
+		close(done)
+	}()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Push(1)
+		}
+	})
+	<-done
+}
+
+func BenchmarkChannelQueue(b *testing.B) {
+	ch := make(chan int, 1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ch <- 1
+			<-ch
+		}
+	})
+}
+
+func BenchmarkMutexQueue(b *testing.B) {
+	var mu sync.Mutex
+	q := NewQueue[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			mu.Lock()
+			q.Push(1)
+			mu.Unlock()
+			for {
+				mu.Lock()
+				item := q.Pop()
+				mu.Unlock()
+				if item != nil {
+					break
+				}
+				runtime.Gosched()
+			}
+		}
+	})
+}
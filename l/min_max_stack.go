@@ -0,0 +1,121 @@
+package l
+
+import "cmp"
+
+// MinMaxStack is a stack that also returns its smallest and largest item in O(1). Every level of the stack
+// remembers the positions of the smallest and the largest item up to it, so Pop restores them without a scan.
+type MinMaxStack[T any] struct {
+	less  func(a, b T) bool
+	items []minMaxEntry[T]
+}
+
+type minMaxEntry[T any] struct {
+	item T
+	// min and max are the indexes of the smallest and the largest item from the bottom of the stack up to this item.
+	min int
+	max int
+}
+
+// NewMinMaxStack creates a MinMaxStack of ordered items with the provided items, the last one on top.
+//
+// Example usage:
+//
+//	s := NewMinMaxStack(3, 1, 4)
+//	*s.Min() // 1
+//	*s.Max() // 4
+//	s.Pop()
+//	*s.Max() // 3
+func NewMinMaxStack[T cmp.Ordered](items ...T) MinMaxStack[T] {
+	return NewMinMaxStackFunc(cmp.Less[T], items...)
+}
+
+// NewMinMaxStackFunc creates a MinMaxStack ordered by the less function with the provided items, the last one on top.
+func NewMinMaxStackFunc[T any](less func(a, b T) bool, items ...T) MinMaxStack[T] {
+	s := MinMaxStack[T]{less: less, items: make([]minMaxEntry[T], 0, len(items))}
+	for _, item := range items {
+		s.Push(item)
+	}
+	return s
+}
+
+// Push adds an item to the top of the stack.
+func (s *MinMaxStack[T]) Push(item T) {
+	index := len(s.items)
+	entry := minMaxEntry[T]{item: item, min: index, max: index}
+	if index > 0 {
+		top := s.items[index-1]
+		// Equal items keep the older position, so Min and Max return the first of them.
+		if !s.less(item, s.items[top.min].item) {
+			entry.min = top.min
+		}
+		if !s.less(s.items[top.max].item, item) {
+			entry.max = top.max
+		}
+	}
+	s.items = append(s.items, entry)
+}
+
+// Pop removes and returns the top item of the stack. If the stack is empty, it returns nil.
+func (s *MinMaxStack[T]) Pop() *T {
+	if len(s.items) == 0 {
+		return nil
+	}
+	item := s.items[len(s.items)-1].item
+	s.items[len(s.items)-1] = minMaxEntry[T]{}
+	s.items = s.items[:len(s.items)-1]
+	return &item
+}
+
+// Peek returns a pointer to a copy of the top item of the stack. If the stack is empty, it returns nil.
+func (s *MinMaxStack[T]) Peek() *T {
+	if len(s.items) == 0 {
+		return nil
+	}
+	item := s.items[len(s.items)-1].item
+	return &item
+}
+
+// Min returns a pointer to a copy of the smallest item of the stack. If the stack is empty, it returns nil.
+func (s *MinMaxStack[T]) Min() *T {
+	if len(s.items) == 0 {
+		return nil
+	}
+	item := s.items[s.items[len(s.items)-1].min].item
+	return &item
+}
+
+// Max returns a pointer to a copy of the largest item of the stack. If the stack is empty, it returns nil.
+func (s *MinMaxStack[T]) Max() *T {
+	if len(s.items) == 0 {
+		return nil
+	}
+	item := s.items[s.items[len(s.items)-1].max].item
+	return &item
+}
+
+// Length returns the number of items in the stack.
+func (s *MinMaxStack[T]) Length() int {
+	return len(s.items)
+}
+
+// IsEmpty returns true if the stack has no items, false otherwise.
+func (s *MinMaxStack[T]) IsEmpty() bool {
+	return len(s.items) == 0
+}
+
+// Clear removes all items from the stack.
+func (s *MinMaxStack[T]) Clear() {
+	s.items = nil
+}
+
+// All returns a function that calls yield with every item of the stack from the top to the bottom,
+// until yield returns false. The items are not removed.
+func (s *MinMaxStack[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for i := len(s.items) - 1; i >= 0; i-- {
+			if !yield(s.items[i].item) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package l
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMinMaxStack(t *testing.T) {
+	s := NewMinMaxStack(3, 1, 4)
+
+	tests := []struct {
+		name     string
+		action   func()
+		min, max int
+	}{
+		{name: "initial items", action: func() {}, min: 1, max: 4},
+		{name: "push a new minimum", action: func() { s.Push(0) }, min: 0, max: 4},
+		{name: "push an item in between", action: func() { s.Push(2) }, min: 0, max: 4},
+		{name: "pop the item in between", action: func() { s.Pop() }, min: 0, max: 4},
+		{name: "pop the minimum", action: func() { s.Pop() }, min: 1, max: 4},
+		{name: "pop the maximum", action: func() { s.Pop() }, min: 1, max: 3},
+		{name: "push a new maximum", action: func() { s.Push(9) }, min: 1, max: 9},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.action()
+			if got := s.Min(); got == nil || *got != tc.min {
+				t.Errorf("Min() = %v, want %d", got, tc.min)
+			}
+			if got := s.Max(); got == nil || *got != tc.max {
+				t.Errorf("Max() = %v, want %d", got, tc.max)
+			}
+		})
+	}
+
+	if got := ToList[int](&s).Slice(); !slices.Equal(got, []int{9, 1, 3}) {
+		t.Errorf("All() = %v, want [9 1 3]", got)
+	}
+	if got := s.Peek(); got == nil || *got != 9 {
+		t.Errorf("Peek() = %v, want 9", got)
+	}
+
+	s.Clear()
+	if !s.IsEmpty() || s.Min() != nil || s.Max() != nil || s.Pop() != nil || s.Peek() != nil {
+		t.Errorf("stack not empty after Clear")
+	}
+}
+
+func TestMinMaxStack_Copies(t *testing.T) {
+	s := NewMinMaxStack(3, 1, 4, 2)
+
+	// Writes through the returned pointers must not change the stack or break its invariants.
+	*s.Peek() = 0
+	*s.Min() = 10
+	*s.Max() = -10
+
+	if got := s.Peek(); got == nil || *got != 2 {
+		t.Errorf("Peek() = %v, want 2", got)
+	}
+	if got := s.Min(); got == nil || *got != 1 {
+		t.Errorf("Min() = %v, want 1", got)
+	}
+	if got := s.Max(); got == nil || *got != 4 {
+		t.Errorf("Max() = %v, want 4", got)
+	}
+	if got := ToList[int](&s).Slice(); !slices.Equal(got, []int{2, 4, 1, 3}) {
+		t.Errorf("All() = %v, want [2 4 1 3]", got)
+	}
+}
+
+func TestMinMaxStack_Func(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	s := NewMinMaxStackFunc(func(a, b task) bool { return a.priority < b.priority })
+	s.Push(task{"a", 2})
+	s.Push(task{"b", 1})
+	s.Push(task{"c", 2})
+	s.Push(task{"d", 1})
+
+	// Equal items keep the first one.
+	if got := s.Min().name; got != "b" {
+		t.Errorf("Min() = %s, want b", got)
+	}
+	if got := s.Max().name; got != "a" {
+		t.Errorf("Max() = %s, want a", got)
+	}
+}
+
+func TestMinMaxStack_Random(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	s := NewMinMaxStack[int]()
+	var reference []int
+
+	for i := 0; i < 2000; i++ {
+		if len(reference) > 0 && random.Intn(3) == 0 {
+			if got := *s.Pop(); got != reference[len(reference)-1] {
+				t.Fatalf("Pop() = %d, want %d", got, reference[len(reference)-1])
+			}
+			reference = reference[:len(reference)-1]
+		} else {
+			item := random.Intn(100)
+			s.Push(item)
+			reference = append(reference, item)
+		}
+
+		if len(reference) == 0 {
+			continue
+		}
+		if *s.Min() != slices.Min(reference) || *s.Max() != slices.Max(reference) {
+			t.Fatalf("Min(), Max() = %d, %d, want %d, %d", *s.Min(), *s.Max(), slices.Min(reference), slices.Max(reference))
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package l
+
+import "cmp"
+
+// MonotonicQueue is a queue that also returns its smallest and largest item in amortized O(1), which makes it
+// suitable for the minimum and maximum of a sliding window. Besides the items, it keeps the candidates for the
+// minimum in ascending order and the candidates for the maximum in descending order: an item that is followed by
+// a smaller item can never be the minimum again, because it leaves the queue first.
+type MonotonicQueue[T any] struct {
+	less  func(a, b T) bool
+	items Queue[T]
+	// pushed and popped count the items that entered and left the queue. They identify the candidates.
+	pushed int
+	popped int
+	mins   []monotonicEntry[T]
+	maxes  []monotonicEntry[T]
+}
+
+type monotonicEntry[T any] struct {
+	item     T
+	sequence int
+}
+
+// NewMonotonicQueue creates an empty MonotonicQueue of ordered items.
+//
+// Example usage:
+//
+//	window := NewMonotonicQueue[float64]()
+//	for _, sample := range samples {
+//	    window.Push(sample)
+//	    if window.Length() > 60 {
+//	        window.Pop()
+//	    }
+//	    fmt.Println(*window.Min(), *window.Max())
+//	}
+func NewMonotonicQueue[T cmp.Ordered]() MonotonicQueue[T] {
+	return NewMonotonicQueueFunc(cmp.Less[T])
+}
+
+// NewMonotonicQueueFunc creates an empty MonotonicQueue ordered by the less function.
+func NewMonotonicQueueFunc[T any](less func(a, b T) bool) MonotonicQueue[T] {
+	return MonotonicQueue[T]{less: less}
+}
+
+// Push appends the item to the end of the queue.
+func (q *MonotonicQueue[T]) Push(item T) {
+	entry := monotonicEntry[T]{item: item, sequence: q.pushed}
+	q.pushed++
+	q.items.Push(item)
+
+	// Equal items are kept, so Min and Max return the oldest of them.
+	for len(q.mins) > 0 && q.less(item, q.mins[len(q.mins)-1].item) {
+		q.mins = q.mins[:len(q.mins)-1]
+	}
+	q.mins = append(q.mins, entry)
+	for len(q.maxes) > 0 && q.less(q.maxes[len(q.maxes)-1].item, item) {
+		q.maxes = q.maxes[:len(q.maxes)-1]
+	}
+	q.maxes = append(q.maxes, entry)
+}
+
+// Pop removes and returns the first item of the queue. If the queue is empty, it returns nil.
+func (q *MonotonicQueue[T]) Pop() *T {
+	item := q.items.Pop()
+	if item == nil {
+		return nil
+	}
+
+	if q.mins[0].sequence == q.popped {
+		q.mins = q.mins[1:]
+	}
+	if q.maxes[0].sequence == q.popped {
+		q.maxes = q.maxes[1:]
+	}
+	q.popped++
+	return item
+}
+
+// Peek returns a pointer to the first item of the queue. If the queue is empty, it returns nil.
+func (q *MonotonicQueue[T]) Peek() *T {
+	return q.items.Peek()
+}
+
+// Min returns a pointer to a copy of the smallest item of the queue. If the queue is empty, it returns nil.
+func (q *MonotonicQueue[T]) Min() *T {
+	if len(q.mins) == 0 {
+		return nil
+	}
+	item := q.mins[0].item
+	return &item
+}
+
+// Max returns a pointer to a copy of the largest item of the queue. If the queue is empty, it returns nil.
+func (q *MonotonicQueue[T]) Max() *T {
+	if len(q.maxes) == 0 {
+		return nil
+	}
+	item := q.maxes[0].item
+	return &item
+}
+
+// Length returns the number of items in the queue.
+func (q *MonotonicQueue[T]) Length() int {
+	return q.items.Length()
+}
+
+// IsEmpty returns true if the queue has no items, false otherwise.
+func (q *MonotonicQueue[T]) IsEmpty() bool {
+	return q.items.IsEmpty()
+}
+
+// Clear removes all items from the queue.
+func (q *MonotonicQueue[T]) Clear() {
+	*q = MonotonicQueue[T]{less: q.less}
+}
+
+// All returns a function that calls yield with every item of the queue from the first to the last,
+// until yield returns false. The items are not removed.
+func (q *MonotonicQueue[T]) All() func(yield func(T) bool) {
+	return q.items.All()
+}
@@ -0,0 +1,78 @@
+package l
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMonotonicQueue(t *testing.T) {
+	q := NewMonotonicQueue[int]()
+
+	tests := []struct {
+		name     string
+		action   func()
+		min, max int
+	}{
+		{name: "first item", action: func() { q.Push(5) }, min: 5, max: 5},
+		{name: "smaller item", action: func() { q.Push(2) }, min: 2, max: 5},
+		{name: "larger item", action: func() { q.Push(8) }, min: 2, max: 8},
+		{name: "equal to the minimum", action: func() { q.Push(2) }, min: 2, max: 8},
+		{name: "pop the old maximum", action: func() { q.Pop() }, min: 2, max: 8},
+		{name: "pop the first minimum", action: func() { q.Pop() }, min: 2, max: 8},
+		{name: "pop the maximum", action: func() { q.Pop() }, min: 2, max: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.action()
+			if got := q.Min(); got == nil || *got != tc.min {
+				t.Errorf("Min() = %v, want %d", got, tc.min)
+			}
+			if got := q.Max(); got == nil || *got != tc.max {
+				t.Errorf("Max() = %v, want %d", got, tc.max)
+			}
+		})
+	}
+
+	q.Push(7)
+	if got := ToList[int](&q).Slice(); !slices.Equal(got, []int{2, 7}) {
+		t.Errorf("All() = %v, want [2 7]", got)
+	}
+	if got := q.Peek(); got == nil || *got != 2 {
+		t.Errorf("Peek() = %v, want 2", got)
+	}
+
+	q.Clear()
+	if !q.IsEmpty() || q.Min() != nil || q.Max() != nil || q.Pop() != nil || q.Peek() != nil {
+		t.Errorf("queue not empty after Clear")
+	}
+	q.Push(1)
+	if *q.Min() != 1 || *q.Pop() != 1 {
+		t.Errorf("queue not usable after Clear")
+	}
+}
+
+func TestMonotonicQueue_SlidingWindow(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	samples := make([]float64, 1000)
+	for i := range samples {
+		samples[i] = float64(random.Intn(50))
+	}
+
+	const size = 7
+	q := NewMonotonicQueue[float64]()
+	for i, sample := range samples {
+		q.Push(sample)
+		if q.Length() > size {
+			if got := *q.Pop(); got != samples[i-size] {
+				t.Fatalf("Pop() = %v, want %v", got, samples[i-size])
+			}
+		}
+
+		window := samples[max(0, i-size+1) : i+1]
+		if *q.Min() != slices.Min(window) || *q.Max() != slices.Max(window) {
+			t.Fatalf("window %d: Min(), Max() = %v, %v, want %v, %v", i, *q.Min(), *q.Max(), slices.Min(window), slices.Max(window))
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package l
+
+// MultiMap is a map in which every key holds a List of values.
+// A MultiMap created by NewMultiMap keeps duplicate values under a key, one created by NewSetMultiMap keeps every
+// value at most once per key. Keys and values are iterated in insertion order.
+type MultiMap[K comparable, V comparable] struct {
+	unique bool
+	values map[K]*multiMapValues[V]
+	keys   []K
+	length int
+}
+
+type multiMapValues[V comparable] struct {
+	items List[V]
+	// counts holds the number of occurrences of every value, which makes Contains run in O(1).
+	counts map[V]int
+}
+
+// NewMultiMap creates an empty MultiMap that allows the same value to be stored several times under a key.
+//
+// Example usage:
+//
+//	m := NewMultiMap[string, int]()
+//	m.Put("a", 1, 2)
+//	m.Put("a", 2)
+//	m.GetAll("a") // [1, 2, 2]
+//	m.Count("a") // 3
+func NewMultiMap[K comparable, V comparable]() MultiMap[K, V] {
+	return MultiMap[K, V]{values: map[K]*multiMapValues[V]{}}
+}
+
+// NewSetMultiMap creates an empty MultiMap that stores every value at most once per key.
+func NewSetMultiMap[K comparable, V comparable]() MultiMap[K, V] {
+	return MultiMap[K, V]{unique: true, values: map[K]*multiMapValues[V]{}}
+}
+
+// Put adds the values under the key. In a MultiMap created by NewSetMultiMap, values that are already stored
+// under the key are skipped. It returns the number of added values.
+func (m *MultiMap[K, V]) Put(key K, values ...V) int {
+	if m.values == nil {
+		m.values = map[K]*multiMapValues[V]{}
+	}
+
+	entry, ok := m.values[key]
+	if !ok {
+		entry = &multiMapValues[V]{counts: map[V]int{}}
+		m.values[key] = entry
+		m.keys = append(m.keys, key)
+	}
+
+	added := 0
+	for _, value := range values {
+		if m.unique && entry.counts[value] > 0 {
+			continue
+		}
+		entry.items.Add(value)
+		entry.counts[value]++
+		added++
+	}
+	m.length += added
+
+	if entry.items.IsEmpty() {
+		m.RemoveAll(key)
+	}
+	return added
+}
+
+// GetAll returns a List with the values stored under the key, in insertion order.
+// If the key is not in the map, the List is empty.
+func (m *MultiMap[K, V]) GetAll(key K) *List[V] {
+	values := NewList[V]()
+	if entry, ok := m.values[key]; ok {
+		values.Add(entry.items.items...)
+	}
+	return &values
+}
+
+// Contains returns true if the value is stored under the key.
+func (m *MultiMap[K, V]) Contains(key K, value V) bool {
+	entry, ok := m.values[key]
+	return ok && entry.counts[value] > 0
+}
+
+// ContainsKey returns true if at least one value is stored under the key.
+func (m *MultiMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.values[key]
+	return ok
+}
+
+// RemoveValue removes the first occurrence of the value under the key. A key without values is removed.
+// It returns true if the value was found.
+func (m *MultiMap[K, V]) RemoveValue(key K, value V) bool {
+	entry, ok := m.values[key]
+	if !ok || entry.counts[value] == 0 {
+		return false
+	}
+
+	for i, item := range entry.items.items {
+		if item == value {
+			entry.items.Remove(i)
+			break
+		}
+	}
+	if entry.counts[value]--; entry.counts[value] == 0 {
+		delete(entry.counts, value)
+	}
+	m.length--
+
+	if entry.items.IsEmpty() {
+		m.RemoveAll(key)
+	}
+	return true
+}
+
+// RemoveAll removes the key and all its values. It returns the number of removed values.
+func (m *MultiMap[K, V]) RemoveAll(key K) int {
+	entry, ok := m.values[key]
+	if !ok {
+		return 0
+	}
+
+	delete(m.values, key)
+	for i, other := range m.keys {
+		if other == key {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+	m.length -= entry.items.Length()
+	return entry.items.Length()
+}
+
+// Keys returns a List with all keys in insertion order.
+func (m *MultiMap[K, V]) Keys() *List[K] {
+	keys := NewList(append([]K(nil), m.keys...)...)
+	return &keys
+}
+
+// Count returns the number of values stored under the key.
+func (m *MultiMap[K, V]) Count(key K) int {
+	if entry, ok := m.values[key]; ok {
+		return entry.items.Length()
+	}
+	return 0
+}
+
+// KeyCount returns the number of keys in the map.
+func (m *MultiMap[K, V]) KeyCount() int {
+	return len(m.keys)
+}
+
+// Length returns the number of values in the map, summed over all keys.
+func (m *MultiMap[K, V]) Length() int {
+	return m.length
+}
+
+// IsEmpty returns true if the map has no values, false otherwise.
+func (m *MultiMap[K, V]) IsEmpty() bool {
+	return m.length == 0
+}
+
+// ForEach calls f with every key and value pair, in insertion order of the keys and then of the values.
+func (m *MultiMap[K, V]) ForEach(f func(key K, value V)) {
+	for _, key := range m.keys {
+		for _, value := range m.values[key].items.items {
+			f(key, value)
+		}
+	}
+}
+
+// All returns a function that calls yield with every key and value pair, in insertion order of the keys and
+// then of the values, until yield returns false.
+func (m *MultiMap[K, V]) All() func(yield func(Entry[K, V]) bool) {
+	return func(yield func(Entry[K, V]) bool) {
+		for _, key := range m.keys {
+			for _, value := range m.values[key].items.items {
+				if !yield(Entry[K, V]{Key: key, Value: value}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Clear removes all keys and values from the map.
+func (m *MultiMap[K, V]) Clear() {
+	m.values = map[K]*multiMapValues[V]{}
+	m.keys = nil
+	m.length = 0
+}
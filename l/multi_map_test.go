@@ -0,0 +1,81 @@
+package l
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMultiMap_Put(t *testing.T) {
+	tests := []struct {
+		name      string
+		multiMap  MultiMap[string, int]
+		wantAdded int
+		wantA     []int
+		wantCount int
+	}{
+		{name: "list values", multiMap: NewMultiMap[string, int](), wantAdded: 5, wantA: []int{1, 2, 2, 1}, wantCount: 5},
+		{name: "set values", multiMap: NewSetMultiMap[string, int](), wantAdded: 3, wantA: []int{1, 2}, wantCount: 3},
+		{name: "zero value", wantAdded: 5, wantA: []int{1, 2, 2, 1}, wantCount: 5},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := tc.multiMap
+			added := m.Put("a", 1, 2, 2)
+			added += m.Put("b", 3)
+			added += m.Put("a", 1)
+
+			if added != tc.wantAdded {
+				t.Errorf("Put() added %d values, want %d", added, tc.wantAdded)
+			}
+			if got := m.GetAll("a").Slice(); !reflect.DeepEqual(got, tc.wantA) {
+				t.Errorf("GetAll(a) = %v, want %v", got, tc.wantA)
+			}
+			if m.Length() != tc.wantCount || m.Count("a") != len(tc.wantA) || m.KeyCount() != 2 {
+				t.Errorf("Length() = %d, Count(a) = %d, KeyCount() = %d", m.Length(), m.Count("a"), m.KeyCount())
+			}
+			if got := m.Keys().Slice(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+				t.Errorf("Keys() = %v, want [a b]", got)
+			}
+			if !m.Contains("a", 2) || m.Contains("b", 2) || m.Contains("c", 1) {
+				t.Errorf("Contains() returned a wrong result")
+			}
+		})
+	}
+}
+
+func TestMultiMap_Remove(t *testing.T) {
+	m := NewMultiMap[string, int]()
+	m.Put("a", 1, 2, 1)
+	m.Put("b", 3)
+	m.Put("c", 4, 5)
+
+	if !m.RemoveValue("a", 1) || m.RemoveValue("a", 9) || m.RemoveValue("x", 1) {
+		t.Errorf("RemoveValue() returned a wrong result")
+	}
+	if got := m.GetAll("a").Slice(); !reflect.DeepEqual(got, []int{2, 1}) {
+		t.Errorf("GetAll(a) after RemoveValue = %v, want [2 1]", got)
+	}
+	if !m.Contains("a", 1) {
+		t.Errorf("Contains(a, 1) = false, the second occurrence was lost")
+	}
+
+	m.RemoveValue("b", 3)
+	if m.ContainsKey("b") || m.KeyCount() != 2 {
+		t.Errorf("key without values was not removed")
+	}
+	if removed := m.RemoveAll("c"); removed != 2 || m.Length() != 2 {
+		t.Errorf("RemoveAll(c) = %d, Length() = %d, want 2, 2", removed, m.Length())
+	}
+
+	pairs := [][2]any{}
+	m.ForEach(func(key string, value int) { pairs = append(pairs, [2]any{key, value}) })
+	if want := [][2]any{{"a", 2}, {"a", 1}}; !reflect.DeepEqual(pairs, want) {
+		t.Errorf("ForEach() visited %v, want %v", pairs, want)
+	}
+
+	m.Clear()
+	if !m.IsEmpty() || m.KeyCount() != 0 || !m.GetAll("a").IsEmpty() {
+		t.Errorf("map not empty after Clear")
+	}
+}
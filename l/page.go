@@ -0,0 +1,129 @@
+package l
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+)
+
+// ErrInvalidCursor is returned by PageAfter when the cursor was not created by PageAfter for the same key type.
+var ErrInvalidCursor = errors.New("l: invalid cursor")
+
+// Page is a page of the items of a List with the metadata that is needed to navigate between pages.
+// It is tagged to be serialized as JSON.
+type Page[T any] struct {
+	Items []T `json:"items"`
+	// PageNumber is the number of the page, starting at 1.
+	PageNumber int `json:"page"`
+	PageSize   int `json:"pageSize"`
+	// Total is the number of items of the list.
+	Total      int  `json:"total"`
+	TotalPages int  `json:"totalPages"`
+	HasNext    bool `json:"hasNext"`
+	HasPrev    bool `json:"hasPrev"`
+}
+
+// CursorPage is a page of the items of a List returned by PageAfter. It is tagged to be serialized as JSON.
+type CursorPage[T any] struct {
+	Items []T `json:"items"`
+	// NextCursor is passed to PageAfter to get the next page. It is empty if there is no next page.
+	NextCursor string `json:"nextCursor,omitempty"`
+	HasNext    bool   `json:"hasNext"`
+}
+
+// pageCursor is the content of an encoded cursor.
+type pageCursor[K any] struct {
+	After K `json:"after"`
+}
+
+// Page returns a copy of the items of the page with the number pageNumber, starting at 1, where every page has
+// pageSize items. A page after the last one has no items. It panics if pageNumber or pageSize is less than 1.
+//
+// Example usage:
+//
+//	l := NewList(1, 2, 3, 4, 5)
+//	page := l.Page(2, 2) // Items: [3, 4], Total: 5, TotalPages: 3, HasNext: true, HasPrev: true
+//	json.Marshal(page)   // {"items":[3,4],"page":2,"pageSize":2,"total":5,"totalPages":3,"hasNext":true,"hasPrev":true}
+func (l *List[T]) Page(pageNumber, pageSize int) Page[T] {
+	if pageNumber < 1 || pageSize < 1 {
+		panic("l: page number and page size must be positive")
+	}
+
+	total := len(l.items)
+	// The page numbers and sizes come from requests, so the computations avoid overflows for large values.
+	totalPages := total / pageSize
+	if total%pageSize != 0 {
+		totalPages++
+	}
+	from := total
+	if pageNumber <= totalPages {
+		from = (pageNumber - 1) * pageSize
+	}
+	to := from + min(pageSize, total-from)
+	return Page[T]{
+		Items:      append([]T{}, l.items[from:to]...),
+		PageNumber: pageNumber,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    pageNumber < totalPages,
+		HasPrev:    pageNumber > 1 && total > 0,
+	}
+}
+
+// PageAfter returns up to pageSize items of the list, which must be sorted by the key, starting after the item of
+// the cursor.
+// An empty cursor returns the first page. The cursor is an opaque string that stores the key of the last item of
+// the previous page, so the pages stay stable when items are added or removed between the requests: no item is
+// returned twice and no item that was in the list for all requests is skipped. For this, the keys of the items
+// must be unique. If the cursor cannot be decoded, it returns ErrInvalidCursor. If the key of the last item cannot
+// be encoded as JSON, like NaN, it returns the error of the encoding. It panics if pageSize is less than 1.
+//
+// The start of the page is found with a binary search, so a request takes O(log n + pageSize) time. If the list is
+// not sorted in ascending order of the key, the pages are unspecified. Since Slice shares its memory with the list,
+// a list can be sorted in place with slices.SortFunc(list.Slice(), ...).
+//
+// Example usage:
+//
+//	users := NewList(User{ID: 1}, User{ID: 2}, User{ID: 3})
+//	id := func(u User) int { return u.ID }
+//	page, _ := PageAfter(&users, id, "", 2)            // Items: [1, 2], HasNext: true
+//	page, _ = PageAfter(&users, id, page.NextCursor, 2) // Items: [3], HasNext: false
+func PageAfter[T any, K cmp.Ordered](list *List[T], key func(T) K, cursor string, pageSize int) (CursorPage[T], error) {
+	if pageSize < 1 {
+		panic("l: page size must be positive")
+	}
+
+	items := list.items
+	from := 0
+	if cursor != "" {
+		data, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil {
+			return CursorPage[T]{}, ErrInvalidCursor
+		}
+		var c pageCursor[K]
+		if err := json.Unmarshal(data, &c); err != nil {
+			return CursorPage[T]{}, ErrInvalidCursor
+		}
+		from, _ = slices.BinarySearchFunc(items, c.After, func(item T, target K) int {
+			// Every item with a key equal to the cursor is before the page.
+			if cmp.Compare(key(item), target) <= 0 {
+				return -1
+			}
+			return 1
+		})
+	}
+
+	to := from + min(pageSize, len(items)-from)
+	page := CursorPage[T]{Items: append([]T{}, items[from:to]...), HasNext: to < len(items)}
+	if page.HasNext {
+		data, err := json.Marshal(pageCursor[K]{After: key(items[to-1])})
+		if err != nil {
+			return CursorPage[T]{}, err
+		}
+		page.NextCursor = base64.RawURLEncoding.EncodeToString(data)
+	}
+	return page, nil
+}
@@ -0,0 +1,174 @@
+package l
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestList_Page(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5)
+	empty := NewList[int]()
+
+	tests := []struct {
+		name     string
+		list     *List[int]
+		number   int
+		size     int
+		expected Page[int]
+	}{
+		{
+			name:     "first page",
+			list:     &list,
+			number:   1,
+			size:     2,
+			expected: Page[int]{Items: []int{1, 2}, PageNumber: 1, PageSize: 2, Total: 5, TotalPages: 3, HasNext: true},
+		},
+		{
+			name:     "middle page",
+			list:     &list,
+			number:   2,
+			size:     2,
+			expected: Page[int]{Items: []int{3, 4}, PageNumber: 2, PageSize: 2, Total: 5, TotalPages: 3, HasNext: true, HasPrev: true},
+		},
+		{
+			name:     "last partial page",
+			list:     &list,
+			number:   3,
+			size:     2,
+			expected: Page[int]{Items: []int{5}, PageNumber: 3, PageSize: 2, Total: 5, TotalPages: 3, HasPrev: true},
+		},
+		{
+			name:     "page after the last one",
+			list:     &list,
+			number:   7,
+			size:     2,
+			expected: Page[int]{Items: []int{}, PageNumber: 7, PageSize: 2, Total: 5, TotalPages: 3, HasPrev: true},
+		},
+		{
+			name:     "page larger than the list",
+			list:     &list,
+			number:   1,
+			size:     10,
+			expected: Page[int]{Items: []int{1, 2, 3, 4, 5}, PageNumber: 1, PageSize: 10, Total: 5, TotalPages: 1},
+		},
+		{
+			name:     "huge page size",
+			list:     &list,
+			number:   2,
+			size:     int(^uint(0) >> 1),
+			expected: Page[int]{Items: []int{}, PageNumber: 2, PageSize: int(^uint(0) >> 1), Total: 5, TotalPages: 1, HasPrev: true},
+		},
+		{
+			name:     "empty list",
+			list:     &empty,
+			number:   1,
+			size:     2,
+			expected: Page[int]{Items: []int{}, PageNumber: 1, PageSize: 2},
+		},
+		{
+			name:     "second page of empty list",
+			list:     &empty,
+			number:   2,
+			size:     2,
+			expected: Page[int]{Items: []int{}, PageNumber: 2, PageSize: 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.list.Page(tc.number, tc.size); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Page() = %+v, want %+v", got, tc.expected)
+			}
+		})
+	}
+
+	assertPanics(t, "Page(0, 1)", func() { list.Page(0, 1) })
+	assertPanics(t, "Page(1, 0)", func() { list.Page(1, 0) })
+}
+
+func TestList_Page_JSON(t *testing.T) {
+	list := NewList("a", "b", "c")
+	page := list.Page(2, 2)
+	page.Items[0] = "z"
+	if *list.Get(2) != "c" {
+		t.Errorf("the items of the page share memory with the list")
+	}
+
+	data, err := json.Marshal(list.Page(3, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"items":[],"page":3,"pageSize":2,"total":3,"totalPages":2,"hasNext":false,"hasPrev":true}`
+	if string(data) != expected {
+		t.Errorf("json.Marshal() = %s, want %s", data, expected)
+	}
+}
+
+type pageUser struct {
+	ID   int
+	Name string
+}
+
+func TestPageAfter(t *testing.T) {
+	users := NewList(pageUser{1, "a"}, pageUser{2, "b"}, pageUser{3, "c"}, pageUser{4, "d"}, pageUser{5, "e"})
+	id := func(u pageUser) int { return u.ID }
+
+	ids := func(page CursorPage[pageUser]) []int {
+		result := []int{}
+		for _, user := range page.Items {
+			result = append(result, user.ID)
+		}
+		return result
+	}
+
+	first, err := PageAfter(&users, id, "", 2)
+	if err != nil || !reflect.DeepEqual(ids(first), []int{1, 2}) || !first.HasNext || first.NextCursor == "" {
+		t.Fatalf("first page = %+v, %v", first, err)
+	}
+
+	// Items added or removed before the cursor do not shift the next page.
+	users.Insert(0, pageUser{0, "z"})
+	users.RemoveIf(func(u pageUser) bool { return u.ID == 2 })
+
+	second, err := PageAfter(&users, id, first.NextCursor, 2)
+	if err != nil || !reflect.DeepEqual(ids(second), []int{3, 4}) || !second.HasNext {
+		t.Fatalf("second page = %+v, %v", second, err)
+	}
+	last, err := PageAfter(&users, id, second.NextCursor, 2)
+	if err != nil || !reflect.DeepEqual(ids(last), []int{5}) || last.HasNext || last.NextCursor != "" {
+		t.Fatalf("last page = %+v, %v", last, err)
+	}
+}
+
+func TestPageAfter_Cursor(t *testing.T) {
+	names := NewList("a", "b", "c")
+	name := func(s string) string { return s }
+	page, _ := PageAfter(&names, name, "", 1)
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "!!"},
+		{name: "not json", cursor: "bm9wZQ"},
+		{name: "other key type", cursor: page.NextCursor},
+	}
+
+	numbers := NewList(1, 2, 3)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := PageAfter(&numbers, func(n int) int { return n }, tc.cursor, 1); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("PageAfter() error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+
+	// A cursor after the last item returns an empty page.
+	empty, err := PageAfter(&names, name, "eyJhZnRlciI6InoifQ", 1)
+	if err != nil || len(empty.Items) != 0 || empty.HasNext {
+		t.Errorf("page after the last item = %+v, %v", empty, err)
+	}
+	assertPanics(t, "PageAfter() with page size 0", func() { PageAfter(&names, name, "", 0) })
+}
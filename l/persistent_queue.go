@@ -0,0 +1,627 @@
+package l
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SyncPolicy controls how often a PersistentQueue flushes its log to stable storage.
+type SyncPolicy int
+
+const (
+	// SyncAlways flushes the log after every write. It is the slowest but the most durable policy.
+	SyncAlways SyncPolicy = iota
+	// SyncBatch flushes the log after every SyncBatchSize writes.
+	SyncBatch
+	// SyncNever leaves flushing to the operating system. The log is only flushed by Sync and Close.
+	SyncNever
+)
+
+var (
+	// ErrQueueClosed is returned by the methods of a PersistentQueue that has been closed.
+	ErrQueueClosed = errors.New("l: queue is closed")
+	// ErrNotReserved is returned by Ack and Nack when the given id does not belong to a reserved item.
+	ErrNotReserved = errors.New("l: item is not reserved")
+)
+
+const (
+	pqSegmentExt = ".wal"
+
+	pqRecordPush   byte = 1
+	pqRecordAck    byte = 2
+	pqRecordNextID byte = 3
+
+	// Every record starts with the length and the CRC-32 of its body, followed by the body:
+	// the record type, the item id and the encoded item (push records only).
+	// A next id record at the start of every new segment holds the id of the next pushed item instead, so the ids
+	// keep increasing across restarts after the segments with the last items were compacted.
+	pqHeaderSize     = 8
+	pqBodyHeaderSize = 9
+
+	pqDefaultSegmentSize       = 4 << 20
+	pqDefaultSyncBatchSize     = 64
+	pqDefaultVisibilityTimeout = 30 * time.Second
+)
+
+// PersistentQueueOptions configures a PersistentQueue. The zero value is valid and selects the defaults.
+type PersistentQueueOptions[T any] struct {
+	// Codec encodes the items written to the log. Defaults to JSONCodec.
+	Codec Codec[T]
+	// Sync selects when the log is flushed to stable storage. Defaults to SyncAlways.
+	Sync SyncPolicy
+	// SyncBatchSize is the number of writes between flushes when Sync is SyncBatch. Defaults to 64.
+	SyncBatchSize int
+	// SegmentSize is the size in bytes after which a new segment file is started. Defaults to 4 MiB.
+	SegmentSize int64
+	// VisibilityTimeout is how long a reserved item stays hidden before it is delivered again. Defaults to 30 seconds.
+	VisibilityTimeout time.Duration
+	// Now returns the current time. Defaults to time.Now; tests may replace it to control reservation expiry.
+	Now func() time.Time
+}
+
+// Delivery is an item handed out by PersistentQueue.Reserve.
+// The item stays in the queue until it is acknowledged with its ID.
+type Delivery[T any] struct {
+	ID       uint64
+	Item     T
+	Deadline time.Time
+}
+
+// PersistentQueue is a FIFO queue that stores its items in a directory of append-only segment files.
+// Every push and acknowledgement is written to the log before it takes effect, so the queue can be restored
+// after a crash by replaying the log. Only the position of every item is kept in memory.
+//
+// Items can be taken either with Pop, which removes them immediately, or with Reserve, which hides them
+// for the visibility timeout until they are acknowledged with Ack. Reservations are not logged, so items that
+// were reserved but not acknowledged before a crash are delivered again after the queue is reopened.
+//
+// A PersistentQueue is safe for concurrent use.
+type PersistentQueue[T any] struct {
+	mu       sync.Mutex
+	dir      string
+	opts     PersistentQueueOptions[T]
+	segments []*pqSegment
+	ready    []pqEntry
+	reserved map[uint64]pqReservation
+	nextID   uint64
+	unsynced int
+	closed   bool
+}
+
+type pqSegment struct {
+	id   uint64
+	file *os.File
+	size int64
+	live int
+}
+
+type pqEntry struct {
+	id      uint64
+	segment *pqSegment
+	offset  int64
+	length  int
+}
+
+type pqReservation struct {
+	entry    pqEntry
+	deadline time.Time
+}
+
+// OpenPersistentQueue opens the queue stored in dir, creating the directory if it does not exist.
+// The log is replayed to restore the items that were pushed but not acknowledged.
+// A record that was only partially written, for example because of a crash, is truncated from the log.
+func OpenPersistentQueue[T any](dir string, opts PersistentQueueOptions[T]) (*PersistentQueue[T], error) {
+	if opts.Codec == nil {
+		opts.Codec = JSONCodec[T]{}
+	}
+	if opts.SyncBatchSize <= 0 {
+		opts.SyncBatchSize = pqDefaultSyncBatchSize
+	}
+	if opts.SegmentSize <= 0 {
+		opts.SegmentSize = pqDefaultSegmentSize
+	}
+	if opts.VisibilityTimeout <= 0 {
+		opts.VisibilityTimeout = pqDefaultVisibilityTimeout
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	q := &PersistentQueue[T]{
+		dir:      dir,
+		opts:     opts,
+		reserved: map[uint64]pqReservation{},
+		nextID:   1,
+	}
+	if err := q.recover(); err != nil {
+		q.closeSegments()
+		return nil, err
+	}
+	return q, nil
+}
+
+// Push appends the given item to the end of the queue.
+// The item is written to the log before Push returns.
+func (q *PersistentQueue[T]) Push(item T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	payload, err := q.opts.Codec.Encode(item)
+	if err != nil {
+		return err
+	}
+
+	id := q.nextID
+	segment, offset, err := q.appendRecord(pqRecordPush, id, payload)
+	if err != nil {
+		return err
+	}
+
+	q.nextID++
+	segment.live++
+	q.ready = append(q.ready, pqEntry{id: id, segment: segment, offset: offset, length: len(payload)})
+	return nil
+}
+
+// Pop removes and returns the first visible item in the queue. If there are no visible items, it returns nil.
+func (q *PersistentQueue[T]) Pop() (*T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil, ErrQueueClosed
+	}
+
+	q.requeueExpired()
+	if len(q.ready) == 0 {
+		return nil, nil
+	}
+
+	entry := q.ready[0]
+	item, err := q.read(entry)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.acknowledge(entry); err != nil {
+		return nil, err
+	}
+
+	q.ready = q.ready[1:]
+	return &item, nil
+}
+
+// Peek returns the first visible item in the queue without removing it. If there are no visible items, it returns nil.
+func (q *PersistentQueue[T]) Peek() (*T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil, ErrQueueClosed
+	}
+
+	q.requeueExpired()
+	if len(q.ready) == 0 {
+		return nil, nil
+	}
+
+	item, err := q.read(q.ready[0])
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Length returns the number of visible items in the queue. Reserved items are not counted.
+func (q *PersistentQueue[T]) Length() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.requeueExpired()
+	return len(q.ready)
+}
+
+// Reserved returns the number of items that are reserved and not yet acknowledged.
+func (q *PersistentQueue[T]) Reserved() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.requeueExpired()
+	return len(q.reserved)
+}
+
+// Reserve hides the first visible item for the visibility timeout and returns it.
+// The item must be acknowledged with Ack before the deadline, otherwise it becomes visible again.
+// If there are no visible items, it returns nil.
+func (q *PersistentQueue[T]) Reserve() (*Delivery[T], error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil, ErrQueueClosed
+	}
+
+	q.requeueExpired()
+	if len(q.ready) == 0 {
+		return nil, nil
+	}
+
+	entry := q.ready[0]
+	item, err := q.read(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	q.ready = q.ready[1:]
+	deadline := q.opts.Now().Add(q.opts.VisibilityTimeout)
+	q.reserved[entry.id] = pqReservation{entry: entry, deadline: deadline}
+	return &Delivery[T]{ID: entry.id, Item: item, Deadline: deadline}, nil
+}
+
+// Ack removes the reserved item with the given id from the queue.
+// It returns ErrNotReserved if the item is not reserved, for example because its reservation has expired.
+func (q *PersistentQueue[T]) Ack(id uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	q.requeueExpired()
+	reservation, ok := q.reserved[id]
+	if !ok {
+		return ErrNotReserved
+	}
+	if err := q.acknowledge(reservation.entry); err != nil {
+		return err
+	}
+
+	delete(q.reserved, id)
+	return nil
+}
+
+// Nack cancels the reservation of the item with the given id and makes it visible again immediately.
+func (q *PersistentQueue[T]) Nack(id uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	q.requeueExpired()
+	reservation, ok := q.reserved[id]
+	if !ok {
+		return ErrNotReserved
+	}
+
+	delete(q.reserved, id)
+	q.insertReady(reservation.entry)
+	return nil
+}
+
+// Compact deletes the oldest segment files whose items have all been acknowledged.
+// Compaction also runs automatically every time a new segment is started.
+func (q *PersistentQueue[T]) Compact() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+	return q.compact()
+}
+
+// Segments returns the number of segment files used by the queue.
+func (q *PersistentQueue[T]) Segments() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.segments)
+}
+
+// Sync flushes the log to stable storage regardless of the sync policy.
+func (q *PersistentQueue[T]) Sync() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+	return q.sync()
+}
+
+// Close flushes the log and closes the segment files. The queue cannot be used after it has been closed.
+func (q *PersistentQueue[T]) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+
+	err := q.sync()
+	if closeErr := q.closeSegments(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// recover loads the existing segments and replays their records.
+func (q *PersistentQueue[T]) recover() error {
+	names, err := filepath.Glob(filepath.Join(q.dir, "*"+pqSegmentExt))
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uint64, 0, len(names))
+	for _, name := range names {
+		id, err := strconv.ParseUint(strings.TrimSuffix(filepath.Base(name), pqSegmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	entries := map[uint64]pqEntry{}
+	for _, id := range ids {
+		segment, err := q.openSegment(id)
+		if err != nil {
+			return err
+		}
+		q.segments = append(q.segments, segment)
+		if err := q.replay(segment, entries); err != nil {
+			return err
+		}
+	}
+
+	if len(q.segments) == 0 {
+		segment, err := q.openSegment(1)
+		if err != nil {
+			return err
+		}
+		q.segments = append(q.segments, segment)
+	}
+
+	// A segment that was cut off right after it was started lost its next id record, and compaction may delete
+	// the segments with the ids, so the record is written again.
+	if q.segments[len(q.segments)-1].size == 0 && q.nextID > 1 {
+		if _, _, err := q.appendRecord(pqRecordNextID, q.nextID, nil); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range entries {
+		entry.segment.live++
+		q.ready = append(q.ready, entry)
+	}
+	sort.Slice(q.ready, func(i, j int) bool { return q.ready[i].id < q.ready[j].id })
+
+	return q.compact()
+}
+
+// replay reads the records of the segment and applies them to entries.
+// The segment is truncated at the first record that is incomplete or fails the checksum.
+func (q *PersistentQueue[T]) replay(segment *pqSegment, entries map[uint64]pqEntry) error {
+	reader := bufio.NewReader(io.NewSectionReader(segment.file, 0, segment.size))
+	header := make([]byte, pqHeaderSize)
+
+	var offset int64
+	for offset < segment.size {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			break
+		}
+		length := int64(binary.BigEndian.Uint32(header[0:]))
+		if length < pqBodyHeaderSize || offset+pqHeaderSize+length > segment.size {
+			break
+		}
+		body := make([]byte, length)
+		if _, err := io.ReadFull(reader, body); err != nil {
+			break
+		}
+		if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(header[4:]) {
+			break
+		}
+
+		id := binary.BigEndian.Uint64(body[1:])
+		switch body[0] {
+		case pqRecordPush:
+			entries[id] = pqEntry{
+				id:      id,
+				segment: segment,
+				offset:  offset + pqHeaderSize + pqBodyHeaderSize,
+				length:  int(length - pqBodyHeaderSize),
+			}
+		case pqRecordAck:
+			delete(entries, id)
+		case pqRecordNextID:
+			// The record holds the next id rather than a used one.
+			id--
+		}
+		if id >= q.nextID {
+			q.nextID = id + 1
+		}
+		offset += pqHeaderSize + length
+	}
+
+	if offset < segment.size {
+		if err := segment.file.Truncate(offset); err != nil {
+			return err
+		}
+		segment.size = offset
+	}
+	return nil
+}
+
+// appendRecord writes a record to the active segment and returns the segment and the offset of the payload.
+func (q *PersistentQueue[T]) appendRecord(kind byte, id uint64, payload []byte) (*pqSegment, int64, error) {
+	segment := q.segments[len(q.segments)-1]
+	if segment.size >= q.opts.SegmentSize {
+		if err := q.roll(); err != nil {
+			return nil, 0, err
+		}
+		segment = q.segments[len(q.segments)-1]
+	}
+
+	record := make([]byte, pqHeaderSize+pqBodyHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(record[0:], uint32(pqBodyHeaderSize+len(payload)))
+	record[pqHeaderSize] = kind
+	binary.BigEndian.PutUint64(record[pqHeaderSize+1:], id)
+	copy(record[pqHeaderSize+pqBodyHeaderSize:], payload)
+	binary.BigEndian.PutUint32(record[4:], crc32.ChecksumIEEE(record[pqHeaderSize:]))
+
+	offset := segment.size
+	if _, err := segment.file.WriteAt(record, offset); err != nil {
+		return nil, 0, err
+	}
+	segment.size += int64(len(record))
+
+	q.unsynced++
+	switch q.opts.Sync {
+	case SyncAlways:
+		if err := q.sync(); err != nil {
+			return nil, 0, err
+		}
+	case SyncBatch:
+		if q.unsynced >= q.opts.SyncBatchSize {
+			if err := q.sync(); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	return segment, offset + pqHeaderSize + pqBodyHeaderSize, nil
+}
+
+// acknowledge logs the removal of the entry.
+func (q *PersistentQueue[T]) acknowledge(entry pqEntry) error {
+	if _, _, err := q.appendRecord(pqRecordAck, entry.id, nil); err != nil {
+		return err
+	}
+	entry.segment.live--
+	return nil
+}
+
+// read decodes the item stored at the position of the entry.
+func (q *PersistentQueue[T]) read(entry pqEntry) (T, error) {
+	payload := make([]byte, entry.length)
+	if _, err := entry.segment.file.ReadAt(payload, entry.offset); err != nil {
+		var zero T
+		return zero, err
+	}
+	return q.opts.Codec.Decode(payload)
+}
+
+// roll flushes the active segment, starts a new one and compacts the log.
+func (q *PersistentQueue[T]) roll() error {
+	if err := q.sync(); err != nil {
+		return err
+	}
+
+	segment, err := q.openSegment(q.segments[len(q.segments)-1].id + 1)
+	if err != nil {
+		return err
+	}
+	q.segments = append(q.segments, segment)
+	if _, _, err := q.appendRecord(pqRecordNextID, q.nextID, nil); err != nil {
+		return err
+	}
+	return q.compact()
+}
+
+// compact deletes the leading segments without live items. Segments are only deleted from the front of the log,
+// because acknowledgements are always written to a later segment than the items they refer to.
+func (q *PersistentQueue[T]) compact() error {
+	for len(q.segments) > 1 && q.segments[0].live == 0 {
+		segment := q.segments[0]
+		if err := segment.file.Close(); err != nil {
+			return err
+		}
+		if err := os.Remove(segment.file.Name()); err != nil {
+			return err
+		}
+		q.segments = q.segments[1:]
+	}
+	return nil
+}
+
+// sync flushes the active segment if there are unsynced writes.
+func (q *PersistentQueue[T]) sync() error {
+	if q.unsynced == 0 || len(q.segments) == 0 {
+		return nil
+	}
+	if err := q.segments[len(q.segments)-1].file.Sync(); err != nil {
+		return err
+	}
+	q.unsynced = 0
+	return nil
+}
+
+// requeueExpired makes the items whose reservation deadline has passed visible again.
+func (q *PersistentQueue[T]) requeueExpired() {
+	if len(q.reserved) == 0 {
+		return
+	}
+
+	now := q.opts.Now()
+	for id, reservation := range q.reserved {
+		if !now.Before(reservation.deadline) {
+			delete(q.reserved, id)
+			q.insertReady(reservation.entry)
+		}
+	}
+}
+
+// insertReady inserts the entry into the visible items, keeping them ordered by id.
+func (q *PersistentQueue[T]) insertReady(entry pqEntry) {
+	index := sort.Search(len(q.ready), func(i int) bool { return q.ready[i].id > entry.id })
+	q.ready = append(q.ready, pqEntry{})
+	copy(q.ready[index+1:], q.ready[index:])
+	q.ready[index] = entry
+}
+
+func (q *PersistentQueue[T]) openSegment(id uint64) (*pqSegment, error) {
+	name := filepath.Join(q.dir, fmt.Sprintf("%020d%s", id, pqSegmentExt))
+	file, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &pqSegment{id: id, file: file, size: info.Size()}, nil
+}
+
+func (q *PersistentQueue[T]) closeSegments() error {
+	var err error
+	for _, segment := range q.segments {
+		if closeErr := segment.file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
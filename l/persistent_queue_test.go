@@ -0,0 +1,320 @@
+package l
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type persistentJob struct {
+	Name     string
+	Attempts int
+}
+
+func openTestQueue[T any](t *testing.T, dir string, opts PersistentQueueOptions[T]) *PersistentQueue[T] {
+	t.Helper()
+	q, err := OpenPersistentQueue[T](dir, opts)
+	if err != nil {
+		t.Fatalf("OpenPersistentQueue() error = %v", err)
+	}
+	return q
+}
+
+func popAll[T any](t *testing.T, q *PersistentQueue[T]) []T {
+	t.Helper()
+	got := []T{}
+	for {
+		item, err := q.Pop()
+		if err != nil {
+			t.Fatalf("Pop() error = %v", err)
+		}
+		if item == nil {
+			return got
+		}
+		got = append(got, *item)
+	}
+}
+
+func TestPersistentQueue_PushPop(t *testing.T) {
+	tests := []struct {
+		name  string
+		codec Codec[persistentJob]
+		sync  SyncPolicy
+		input []persistentJob
+	}{
+		{
+			name:  "empty queue",
+			codec: JSONCodec[persistentJob]{},
+			input: []persistentJob{},
+		},
+		{
+			name:  "json codec",
+			codec: JSONCodec[persistentJob]{},
+			sync:  SyncAlways,
+			input: []persistentJob{{Name: "a"}, {Name: "b", Attempts: 2}, {Name: "c"}},
+		},
+		{
+			name:  "gob codec",
+			codec: GobCodec[persistentJob]{},
+			sync:  SyncBatch,
+			input: []persistentJob{{Name: "a", Attempts: 1}, {Name: "b"}},
+		},
+		{
+			name:  "no sync",
+			codec: JSONCodec[persistentJob]{},
+			sync:  SyncNever,
+			input: []persistentJob{{Name: "a"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := openTestQueue(t, t.TempDir(), PersistentQueueOptions[persistentJob]{Codec: tc.codec, Sync: tc.sync})
+			defer q.Close()
+
+			for _, item := range tc.input {
+				if err := q.Push(item); err != nil {
+					t.Fatalf("Push() error = %v", err)
+				}
+			}
+			if got := q.Length(); got != len(tc.input) {
+				t.Errorf("Length() = %d, want %d", got, len(tc.input))
+			}
+			if len(tc.input) > 0 {
+				if got, err := q.Peek(); err != nil || got == nil || *got != tc.input[0] {
+					t.Errorf("Peek() = %v, %v, want %v", got, err, tc.input[0])
+				}
+			}
+			if got := popAll(t, q); !reflect.DeepEqual(got, tc.input) {
+				t.Errorf("Pop() sequence = %v, want %v", got, tc.input)
+			}
+		})
+	}
+}
+
+func TestPersistentQueue_Recovery(t *testing.T) {
+	dir := t.TempDir()
+
+	q := openTestQueue(t, dir, PersistentQueueOptions[int]{})
+	for i := 1; i <= 5; i++ {
+		if err := q.Push(i); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+	if _, err := q.Pop(); err != nil {
+		t.Fatalf("Pop() error = %v", err)
+	}
+	delivery, err := q.Reserve()
+	if err != nil || delivery == nil {
+		t.Fatalf("Reserve() = %v, %v", delivery, err)
+	}
+	// The queue is reopened without Close to simulate a crash.
+
+	reopened := openTestQueue(t, dir, PersistentQueueOptions[int]{})
+	defer reopened.Close()
+
+	if got, want := popAll(t, reopened), []int{2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items after recovery = %v, want %v", got, want)
+	}
+	if err := reopened.Push(6); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if got, _ := reopened.Pop(); got == nil || *got != 6 {
+		t.Errorf("Pop() after recovery = %v, want 6", got)
+	}
+}
+
+func TestPersistentQueue_TornWrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		garbage []byte
+	}{
+		{
+			name:    "truncated header",
+			garbage: []byte{0, 0},
+		},
+		{
+			name:    "truncated body",
+			garbage: []byte{0, 0, 0, 20, 1, 2, 3, 4, 1, 0},
+		},
+		{
+			name:    "checksum mismatch",
+			garbage: []byte{0, 0, 0, 9, 1, 2, 3, 4, 1, 0, 0, 0, 0, 0, 0, 0, 9},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			q := openTestQueue(t, dir, PersistentQueueOptions[string]{})
+			for _, item := range []string{"a", "b"} {
+				if err := q.Push(item); err != nil {
+					t.Fatalf("Push() error = %v", err)
+				}
+			}
+			if err := q.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			names, _ := filepath.Glob(filepath.Join(dir, "*.wal"))
+			file, err := os.OpenFile(names[len(names)-1], os.O_APPEND|os.O_WRONLY, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := file.Write(tc.garbage); err != nil {
+				t.Fatal(err)
+			}
+			file.Close()
+
+			reopened := openTestQueue(t, dir, PersistentQueueOptions[string]{})
+			defer reopened.Close()
+			if err := reopened.Push("c"); err != nil {
+				t.Fatalf("Push() error = %v", err)
+			}
+			if got, want := popAll(t, reopened), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("items after recovery = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPersistentQueue_Reserve(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := openTestQueue(t, t.TempDir(), PersistentQueueOptions[string]{
+		VisibilityTimeout: time.Minute,
+		Now:               func() time.Time { return now },
+	})
+	defer q.Close()
+
+	for _, item := range []string{"a", "b", "c"} {
+		if err := q.Push(item); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+
+	first, _ := q.Reserve()
+	second, _ := q.Reserve()
+	if first.Item != "a" || second.Item != "b" {
+		t.Fatalf("Reserve() items = %v, %v, want a, b", first.Item, second.Item)
+	}
+	if got := q.Length(); got != 1 {
+		t.Errorf("Length() with reservations = %d, want 1", got)
+	}
+	if got := q.Reserved(); got != 2 {
+		t.Errorf("Reserved() = %d, want 2", got)
+	}
+
+	if err := q.Ack(second.ID); err != nil {
+		t.Errorf("Ack() error = %v", err)
+	}
+	if err := q.Ack(second.ID); !errors.Is(err, ErrNotReserved) {
+		t.Errorf("second Ack() error = %v, want %v", err, ErrNotReserved)
+	}
+
+	now = now.Add(time.Minute)
+	if err := q.Ack(first.ID); !errors.Is(err, ErrNotReserved) {
+		t.Errorf("Ack() after expiry error = %v, want %v", err, ErrNotReserved)
+	}
+
+	third, _ := q.Reserve()
+	if third.Item != "a" {
+		t.Errorf("Reserve() after expiry = %v, want a", third.Item)
+	}
+	if err := q.Nack(third.ID); err != nil {
+		t.Errorf("Nack() error = %v", err)
+	}
+	if got, want := popAll(t, q), []string{"a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("remaining items = %v, want %v", got, want)
+	}
+}
+
+func TestPersistentQueue_Compact(t *testing.T) {
+	dir := t.TempDir()
+	q := openTestQueue(t, dir, PersistentQueueOptions[int]{SegmentSize: 64})
+
+	for i := 0; i < 20; i++ {
+		if err := q.Push(i); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+	segments := q.Segments()
+	if segments < 2 {
+		t.Fatalf("Segments() = %d, want at least 2", segments)
+	}
+
+	for i := 0; i < 15; i++ {
+		if _, err := q.Pop(); err != nil {
+			t.Fatalf("Pop() error = %v", err)
+		}
+	}
+	if err := q.Compact(); err != nil {
+		t.Fatalf("Compact() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "00000000000000000001.wal")); !os.IsNotExist(err) {
+		t.Errorf("first segment still exists after Compact, Stat() error = %v", err)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := q.Pop(); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Pop() after Close error = %v, want %v", err, ErrQueueClosed)
+	}
+
+	reopened := openTestQueue(t, dir, PersistentQueueOptions[int]{SegmentSize: 64})
+	defer reopened.Close()
+	if got, want := popAll(t, reopened), []int{15, 16, 17, 18, 19}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items after reopen = %v, want %v", got, want)
+	}
+}
+
+func TestPersistentQueue_IDsAfterCompact(t *testing.T) {
+	dir := t.TempDir()
+	q := openTestQueue(t, dir, PersistentQueueOptions[string]{SegmentSize: 64})
+
+	for _, item := range []string{"a", "b", "c"} {
+		if err := q.Push(item); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+	deliveries := make([]*Delivery[string], 3)
+	for i := range deliveries {
+		deliveries[i], _ = q.Reserve()
+	}
+
+	// The item with the highest id is acknowledged first, so the last segment only refers to lower ids
+	// once the segments with the items are compacted.
+	for _, i := range []int{2, 0, 1} {
+		if err := q.Ack(deliveries[i].ID); err != nil {
+			t.Fatalf("Ack() error = %v", err)
+		}
+	}
+	if err := q.Compact(); err != nil {
+		t.Fatalf("Compact() error = %v", err)
+	}
+	if got := q.Segments(); got != 1 {
+		t.Fatalf("Segments() = %d, want 1", got)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	for restart := 0; restart < 2; restart++ {
+		reopened := openTestQueue(t, dir, PersistentQueueOptions[string]{SegmentSize: 64})
+		if err := reopened.Push("d"); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+		delivery, _ := reopened.Reserve()
+		if want := deliveries[2].ID + uint64(restart) + 1; delivery.ID != want {
+			t.Errorf("restart %d: ID = %d, want %d", restart, delivery.ID, want)
+		}
+		if err := reopened.Ack(delivery.ID); err != nil {
+			t.Fatalf("Ack() error = %v", err)
+		}
+		if err := reopened.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	}
+}
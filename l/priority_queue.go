@@ -0,0 +1,110 @@
+package l
+
+// PriorityQueue is a queue that always returns its smallest item first, as defined by a less function.
+// The items are kept in a binary heap, so Push and Pop run in O(log n) and Peek runs in O(1).
+// Items that are equal according to the less function are returned in no particular order.
+type PriorityQueue[T any] struct {
+	less  func(a, b T) bool
+	items []T
+}
+
+// NewPriorityQueue creates a PriorityQueue ordered by the less function with the provided items.
+//
+// Example usage:
+//
+//	q := NewPriorityQueue(func(a, b int) bool { return a < b }, 5, 1, 3)
+//	*q.Pop() // 1
+//	*q.Pop() // 3
+func NewPriorityQueue[T any](less func(a, b T) bool, items ...T) PriorityQueue[T] {
+	q := PriorityQueue[T]{less: less, items: items}
+	for i := len(items)/2 - 1; i >= 0; i-- {
+		q.down(i)
+	}
+	return q
+}
+
+// Push adds an item to the queue.
+func (q *PriorityQueue[T]) Push(item T) {
+	q.items = append(q.items, item)
+	q.up(len(q.items) - 1)
+}
+
+// Pop removes and returns the smallest item. If the queue is empty, it returns nil.
+func (q *PriorityQueue[T]) Pop() *T {
+	if len(q.items) == 0 {
+		return nil
+	}
+
+	item := q.items[0]
+	last := len(q.items) - 1
+	q.items[0] = q.items[last]
+	var zero T
+	q.items[last] = zero
+	q.items = q.items[:last]
+	q.down(0)
+	return &item
+}
+
+// Peek returns a pointer to the smallest item without removing it. If the queue is empty, it returns nil.
+// The item must not be modified in a way that changes its order.
+func (q *PriorityQueue[T]) Peek() *T {
+	if len(q.items) == 0 {
+		return nil
+	}
+	return &q.items[0]
+}
+
+// Length returns the number of items in the queue.
+func (q *PriorityQueue[T]) Length() int {
+	return len(q.items)
+}
+
+// IsEmpty returns true if the queue has no items, false otherwise.
+func (q *PriorityQueue[T]) IsEmpty() bool {
+	return len(q.items) == 0
+}
+
+// Clear removes all items from the queue.
+func (q *PriorityQueue[T]) Clear() {
+	q.items = nil
+}
+
+// All returns a function that calls yield with every item of the queue in no particular order,
+// until yield returns false. The items are not removed.
+func (q *PriorityQueue[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for _, item := range q.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+func (q *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(q.items[i], q.items[parent]) {
+			return
+		}
+		q.items[i], q.items[parent] = q.items[parent], q.items[i]
+		i = parent
+	}
+}
+
+func (q *PriorityQueue[T]) down(i int) {
+	for {
+		smallest := i
+		if left := 2*i + 1; left < len(q.items) && q.less(q.items[left], q.items[smallest]) {
+			smallest = left
+		}
+		if right := 2*i + 2; right < len(q.items) && q.less(q.items[right], q.items[smallest]) {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		q.items[i], q.items[smallest] = q.items[smallest], q.items[i]
+		i = smallest
+	}
+}
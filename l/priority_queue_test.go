@@ -0,0 +1,75 @@
+package l
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial []int
+		push    []int
+		less    func(a, b int) bool
+		want    []int
+	}{
+		{name: "empty", less: func(a, b int) bool { return a < b }, want: []int{}},
+		{name: "initial items", initial: []int{5, 1, 4, 2, 3}, less: func(a, b int) bool { return a < b }, want: []int{1, 2, 3, 4, 5}},
+		{name: "pushed items", push: []int{3, 3, 1, 2}, less: func(a, b int) bool { return a < b }, want: []int{1, 2, 3, 3}},
+		{name: "max first", initial: []int{2, 7}, push: []int{9, 1}, less: func(a, b int) bool { return a > b }, want: []int{9, 7, 2, 1}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewPriorityQueue(tc.less, tc.initial...)
+			for _, item := range tc.push {
+				q.Push(item)
+			}
+			if q.Length() != len(tc.want) {
+				t.Errorf("Length() = %d, want %d", q.Length(), len(tc.want))
+			}
+
+			got := []int{}
+			for !q.IsEmpty() {
+				peek := *q.Peek()
+				item := *q.Pop()
+				if peek != item {
+					t.Errorf("Peek() = %d, Pop() = %d", peek, item)
+				}
+				got = append(got, item)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Pop() order = %v, want %v", got, tc.want)
+			}
+			if q.Pop() != nil || q.Peek() != nil {
+				t.Errorf("empty queue returned an item")
+			}
+		})
+	}
+}
+
+func TestPriorityQueue_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	q := NewPriorityQueue(func(a, b int) bool { return a < b })
+	want := []int{}
+	for i := 0; i < 1000; i++ {
+		item := rnd.Intn(100)
+		q.Push(item)
+		want = append(want, item)
+	}
+	sort.Ints(want)
+
+	for i, item := range want {
+		if got := *q.Pop(); got != item {
+			t.Fatalf("Pop() #%d = %d, want %d", i, got, item)
+		}
+	}
+
+	q.Push(1)
+	q.Clear()
+	if !q.IsEmpty() {
+		t.Errorf("queue not empty after Clear")
+	}
+}
@@ -43,3 +43,25 @@ func (q *Queue[T]) Peek() *T {
 
 	return &q.items[0]
 }
+
+// IsEmpty returns true if the queue has no items, false otherwise.
+func (q *Queue[T]) IsEmpty() bool {
+	return len(q.items) == 0
+}
+
+// Clear removes all items from the queue.
+func (q *Queue[T]) Clear() {
+	q.items = nil
+}
+
+// All returns a function that calls yield with every item of the queue from the first to the last,
+// until yield returns false. The items are not removed.
+func (q *Queue[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for _, item := range q.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,460 @@
+package l
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"sort"
+)
+
+const (
+	roaringBitmapTag byte = 'R'
+
+	// roaringArrayMax is the largest cardinality stored as a sorted array. Above it a bitmap takes less memory.
+	roaringArrayMax = 4096
+	roaringWords    = 1 << 16 / 64
+
+	roaringArrayKind  byte = 0
+	roaringBitmapKind byte = 1
+)
+
+// RoaringBitmap is a compressed set of uint32 values for sparse data. The values are partitioned into chunks of
+// 65536 by their upper 16 bits, and every chunk is stored either as a sorted array of the lower 16 bits or,
+// when it holds more than 4096 values, as a bitmap. It provides the same operations as BitSet.
+// The zero value is an empty set ready to use.
+type RoaringBitmap struct {
+	keys       []uint16
+	containers []*roaringContainer
+}
+
+// roaringContainer holds the lower 16 bits of the values of a chunk. Exactly one of array and bitmap is used.
+type roaringContainer struct {
+	array  []uint16
+	bitmap []uint64
+	count  int
+}
+
+// NewRoaringBitmap creates a RoaringBitmap with the given values.
+func NewRoaringBitmap(values ...uint32) RoaringBitmap {
+	r := RoaringBitmap{}
+	for _, value := range values {
+		r.Set(value)
+	}
+	return r
+}
+
+// Set adds the value to the set.
+func (r *RoaringBitmap) Set(value uint32) {
+	key, low := uint16(value>>16), uint16(value)
+	index, found := r.find(key)
+	if !found {
+		r.keys = append(r.keys, 0)
+		copy(r.keys[index+1:], r.keys[index:])
+		r.keys[index] = key
+		r.containers = append(r.containers, nil)
+		copy(r.containers[index+1:], r.containers[index:])
+		r.containers[index] = &roaringContainer{}
+	}
+	r.containers[index].set(low)
+}
+
+// Reset removes the value from the set.
+func (r *RoaringBitmap) Reset(value uint32) {
+	index, found := r.find(uint16(value >> 16))
+	if !found {
+		return
+	}
+
+	container := r.containers[index]
+	container.clear(uint16(value))
+	if container.count == 0 {
+		r.keys = append(r.keys[:index], r.keys[index+1:]...)
+		r.containers = append(r.containers[:index], r.containers[index+1:]...)
+	}
+}
+
+// Test returns true if the value is in the set.
+func (r *RoaringBitmap) Test(value uint32) bool {
+	index, found := r.find(uint16(value >> 16))
+	return found && r.containers[index].test(uint16(value))
+}
+
+// Flip adds the value to the set if it is missing and removes it otherwise.
+func (r *RoaringBitmap) Flip(value uint32) {
+	if r.Test(value) {
+		r.Reset(value)
+	} else {
+		r.Set(value)
+	}
+}
+
+// Count returns the number of values in the set.
+func (r *RoaringBitmap) Count() int {
+	count := 0
+	for _, container := range r.containers {
+		count += container.count
+	}
+	return count
+}
+
+// Length returns the number of values in the set, like Count.
+func (r *RoaringBitmap) Length() int {
+	return r.Count()
+}
+
+// IsEmpty returns true if the set has no values, false otherwise.
+func (r *RoaringBitmap) IsEmpty() bool {
+	return len(r.containers) == 0
+}
+
+// Clear removes all values from the set.
+func (r *RoaringBitmap) Clear() {
+	r.keys = nil
+	r.containers = nil
+}
+
+// NextSet returns the smallest value in the set that is greater than or equal to from.
+// The second result is false if there is no such value.
+func (r *RoaringBitmap) NextSet(from uint32) (uint32, bool) {
+	index, _ := r.find(uint16(from >> 16))
+	for ; index < len(r.keys); index++ {
+		low := 0
+		if r.keys[index] == uint16(from>>16) {
+			low = int(uint16(from))
+		}
+		if next := r.containers[index].next(low); next >= 0 {
+			return uint32(r.keys[index])<<16 | uint32(next), true
+		}
+	}
+	return 0, false
+}
+
+// ForEach calls f for every value in the set in ascending order.
+func (r *RoaringBitmap) ForEach(f func(value uint32)) {
+	for i, container := range r.containers {
+		high := uint32(r.keys[i]) << 16
+		container.forEach(func(low uint16) bool {
+			f(high | uint32(low))
+			return true
+		})
+	}
+}
+
+// All returns a function that calls yield with every value in the set in ascending order, until yield returns false.
+func (r *RoaringBitmap) All() func(yield func(uint32) bool) {
+	return func(yield func(uint32) bool) {
+		for i, container := range r.containers {
+			high := uint32(r.keys[i]) << 16
+			if !container.forEach(func(low uint16) bool { return yield(high | uint32(low)) }) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a List with all values of the set in ascending order.
+func (r *RoaringBitmap) Values() *List[uint32] {
+	values := NewList[uint32]()
+	r.ForEach(func(value uint32) { values.Add(value) })
+	return &values
+}
+
+// Equal returns true if both sets contain the same values.
+func (r *RoaringBitmap) Equal(other *RoaringBitmap) bool {
+	if len(r.keys) != len(other.keys) {
+		return false
+	}
+	for i, key := range r.keys {
+		if key != other.keys[i] || r.containers[i].count != other.containers[i].count {
+			return false
+		}
+		a, b := r.containers[i].words(), other.containers[i].words()
+		for j := range a {
+			if a[j] != b[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// And returns a new set with the values that are in both sets.
+func (r *RoaringBitmap) And(other *RoaringBitmap) *RoaringBitmap {
+	return r.combine(other, false, false, func(x, y uint64) uint64 { return x & y })
+}
+
+// Or returns a new set with the values that are in either set.
+func (r *RoaringBitmap) Or(other *RoaringBitmap) *RoaringBitmap {
+	return r.combine(other, true, true, func(x, y uint64) uint64 { return x | y })
+}
+
+// Xor returns a new set with the values that are in exactly one of the sets.
+func (r *RoaringBitmap) Xor(other *RoaringBitmap) *RoaringBitmap {
+	return r.combine(other, true, true, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// AndNot returns a new set with the values that are in r but not in other.
+func (r *RoaringBitmap) AndNot(other *RoaringBitmap) *RoaringBitmap {
+	return r.combine(other, true, false, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// MarshalBinary encodes the set into a binary form.
+func (r *RoaringBitmap) MarshalBinary() ([]byte, error) {
+	data := marshalHeader(roaringBitmapTag, uint64(len(r.keys)))
+	for i, container := range r.containers {
+		data = binary.BigEndian.AppendUint16(data, r.keys[i])
+		data = binary.BigEndian.AppendUint32(data, uint32(container.count))
+		if container.bitmap != nil {
+			data = append(data, roaringBitmapKind)
+			for _, word := range container.bitmap {
+				data = binary.BigEndian.AppendUint64(data, word)
+			}
+		} else {
+			data = append(data, roaringArrayKind)
+			for _, low := range container.array {
+				data = binary.BigEndian.AppendUint16(data, low)
+			}
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary restores a set encoded by MarshalBinary.
+func (r *RoaringBitmap) UnmarshalBinary(data []byte) error {
+	params, data, err := unmarshalHeader(data, roaringBitmapTag, 1)
+	if err != nil {
+		return err
+	}
+
+	count := params[0]
+	if count > 1<<16 {
+		return ErrInvalidData
+	}
+	keys := make([]uint16, 0, count)
+	containers := make([]*roaringContainer, 0, count)
+	for i := uint64(0); i < count; i++ {
+		if len(data) < 7 {
+			return ErrInvalidData
+		}
+		key := binary.BigEndian.Uint16(data)
+		container := &roaringContainer{count: int(binary.BigEndian.Uint32(data[2:]))}
+		kind := data[6]
+		data = data[7:]
+
+		switch {
+		case kind == roaringBitmapKind && len(data) >= roaringWords*8:
+			container.bitmap = make([]uint64, roaringWords)
+			ones := 0
+			for j := range container.bitmap {
+				container.bitmap[j] = binary.BigEndian.Uint64(data[8*j:])
+				ones += bits.OnesCount64(container.bitmap[j])
+			}
+			data = data[roaringWords*8:]
+			// Smaller containers are always stored as arrays.
+			if ones != container.count || ones <= roaringArrayMax {
+				return ErrInvalidData
+			}
+		case kind == roaringArrayKind && container.count <= roaringArrayMax && len(data) >= container.count*2:
+			container.array = make([]uint16, container.count)
+			for j := range container.array {
+				container.array[j] = binary.BigEndian.Uint16(data[2*j:])
+				// The binary searches of the containers need strictly ascending values.
+				if j > 0 && container.array[j] <= container.array[j-1] {
+					return ErrInvalidData
+				}
+			}
+			data = data[container.count*2:]
+		default:
+			return ErrInvalidData
+		}
+		if container.count == 0 || (len(keys) > 0 && key <= keys[len(keys)-1]) {
+			return ErrInvalidData
+		}
+		keys = append(keys, key)
+		containers = append(containers, container)
+	}
+	if len(data) != 0 {
+		return ErrInvalidData
+	}
+
+	r.keys = keys
+	r.containers = containers
+	return nil
+}
+
+// find returns the index of the container with the given key, or the index at which it would be inserted.
+func (r *RoaringBitmap) find(key uint16) (int, bool) {
+	index := sort.Search(len(r.keys), func(i int) bool { return r.keys[i] >= key })
+	return index, index < len(r.keys) && r.keys[index] == key
+}
+
+// combine applies op to the containers of both sets. keepLeft and keepRight tell whether a container present in
+// only one of the sets is copied to the result.
+func (r *RoaringBitmap) combine(other *RoaringBitmap, keepLeft, keepRight bool, op func(x, y uint64) uint64) *RoaringBitmap {
+	result := &RoaringBitmap{}
+	add := func(key uint16, container *roaringContainer) {
+		if container.count > 0 {
+			result.keys = append(result.keys, key)
+			result.containers = append(result.containers, container)
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(r.keys) || j < len(other.keys) {
+		switch {
+		case j == len(other.keys) || (i < len(r.keys) && r.keys[i] < other.keys[j]):
+			if keepLeft {
+				add(r.keys[i], r.containers[i].clone())
+			}
+			i++
+		case i == len(r.keys) || other.keys[j] < r.keys[i]:
+			if keepRight {
+				add(other.keys[j], other.containers[j].clone())
+			}
+			j++
+		default:
+			a, b := r.containers[i].words(), other.containers[j].words()
+			words := make([]uint64, roaringWords)
+			for k := range words {
+				words[k] = op(a[k], b[k])
+			}
+			add(r.keys[i], newRoaringContainer(words))
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// newRoaringContainer creates a container from a bitmap, converting it to an array if it is sparse.
+func newRoaringContainer(words []uint64) *roaringContainer {
+	c := &roaringContainer{bitmap: words}
+	for _, word := range words {
+		c.count += bits.OnesCount64(word)
+	}
+	if c.count <= roaringArrayMax {
+		c.toArray()
+	}
+	return c
+}
+
+func (c *roaringContainer) set(low uint16) {
+	if c.bitmap != nil {
+		if c.bitmap[low/64]&(1<<(low%64)) == 0 {
+			c.bitmap[low/64] |= 1 << (low % 64)
+			c.count++
+		}
+		return
+	}
+
+	index := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= low })
+	if index < len(c.array) && c.array[index] == low {
+		return
+	}
+	c.array = append(c.array, 0)
+	copy(c.array[index+1:], c.array[index:])
+	c.array[index] = low
+	c.count++
+	if c.count > roaringArrayMax {
+		c.bitmap = c.words()
+		c.array = nil
+	}
+}
+
+func (c *roaringContainer) clear(low uint16) {
+	if c.bitmap != nil {
+		if c.bitmap[low/64]&(1<<(low%64)) != 0 {
+			c.bitmap[low/64] &^= 1 << (low % 64)
+			c.count--
+			if c.count <= roaringArrayMax {
+				c.toArray()
+			}
+		}
+		return
+	}
+
+	index := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= low })
+	if index < len(c.array) && c.array[index] == low {
+		c.array = append(c.array[:index], c.array[index+1:]...)
+		c.count--
+	}
+}
+
+func (c *roaringContainer) test(low uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[low/64]&(1<<(low%64)) != 0
+	}
+	index := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= low })
+	return index < len(c.array) && c.array[index] == low
+}
+
+// next returns the smallest value in the container that is greater than or equal to from, or -1.
+func (c *roaringContainer) next(from int) int {
+	if c.bitmap != nil {
+		for word := from / 64; word < roaringWords; word++ {
+			value := c.bitmap[word]
+			if word == from/64 {
+				value &= ^uint64(0) << (from % 64)
+			}
+			if value != 0 {
+				return word*64 + bits.TrailingZeros64(value)
+			}
+		}
+		return -1
+	}
+
+	index := sort.Search(len(c.array), func(i int) bool { return int(c.array[i]) >= from })
+	if index < len(c.array) {
+		return int(c.array[index])
+	}
+	return -1
+}
+
+func (c *roaringContainer) forEach(f func(low uint16) bool) bool {
+	if c.bitmap == nil {
+		for _, low := range c.array {
+			if !f(low) {
+				return false
+			}
+		}
+		return true
+	}
+	for word, value := range c.bitmap {
+		for value != 0 {
+			if !f(uint16(word*64 + bits.TrailingZeros64(value))) {
+				return false
+			}
+			value &= value - 1
+		}
+	}
+	return true
+}
+
+// words returns the container as a bitmap. The result must not be modified if the container is a bitmap.
+func (c *roaringContainer) words() []uint64 {
+	if c.bitmap != nil {
+		return c.bitmap
+	}
+	words := make([]uint64, roaringWords)
+	for _, low := range c.array {
+		words[low/64] |= 1 << (low % 64)
+	}
+	return words
+}
+
+func (c *roaringContainer) toArray() {
+	array := make([]uint16, 0, c.count)
+	c.forEach(func(low uint16) bool {
+		array = append(array, low)
+		return true
+	})
+	c.array = array
+	c.bitmap = nil
+}
+
+func (c *roaringContainer) clone() *roaringContainer {
+	return &roaringContainer{
+		array:  append([]uint16(nil), c.array...),
+		bitmap: append([]uint64(nil), c.bitmap...),
+		count:  c.count,
+	}
+}
@@ -0,0 +1,193 @@
+package l
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// randomRoaringPair returns a RoaringBitmap and an equivalent BitSet. The values are clustered so that both
+// array and bitmap containers are used.
+func randomRoaringPair(rnd *rand.Rand) (RoaringBitmap, BitSet) {
+	r := NewRoaringBitmap()
+	b := BitSet{}
+	for i := 0; i < 20000; i++ {
+		var value uint32
+		if rnd.Intn(2) == 0 {
+			value = uint32(rnd.Intn(8000))
+		} else {
+			value = uint32(rnd.Intn(1 << 22))
+		}
+		r.Set(value)
+		b.Set(int(value))
+	}
+	for i := 0; i < 3000; i++ {
+		value := uint32(rnd.Intn(8000))
+		r.Reset(value)
+		b.Reset(int(value))
+	}
+	return r, b
+}
+
+func roaringValues(r *RoaringBitmap) []int {
+	values := []int{}
+	r.ForEach(func(value uint32) { values = append(values, int(value)) })
+	return values
+}
+
+func TestRoaringBitmap_MatchesBitSet(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	r1, b1 := randomRoaringPair(rnd)
+	r2, b2 := randomRoaringPair(rnd)
+
+	tests := []struct {
+		name    string
+		roaring *RoaringBitmap
+		bitset  *BitSet
+	}{
+		{name: "set and clear", roaring: &r1, bitset: &b1},
+		{name: "and", roaring: r1.And(&r2), bitset: b1.And(&b2)},
+		{name: "or", roaring: r1.Or(&r2), bitset: b1.Or(&b2)},
+		{name: "xor", roaring: r1.Xor(&r2), bitset: b1.Xor(&b2)},
+		{name: "and not", roaring: r1.AndNot(&r2), bitset: b1.AndNot(&b2)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := roaringValues(tc.roaring), tc.bitset.Indexes().Slice(); !reflect.DeepEqual(got, want) {
+				t.Fatalf("values differ: got %d values, want %d", len(got), len(want))
+			}
+			if tc.roaring.Count() != tc.bitset.Count() {
+				t.Errorf("Count() = %d, want %d", tc.roaring.Count(), tc.bitset.Count())
+			}
+			for _, from := range []int{0, 100, 7999, 65536, 1 << 21} {
+				got, ok := tc.roaring.NextSet(uint32(from))
+				want := tc.bitset.NextSet(from)
+				if (want < 0) == ok || (ok && int(got) != want) {
+					t.Errorf("NextSet(%d) = %d, %v, want %d", from, got, ok, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRoaringBitmap_Containers(t *testing.T) {
+	r := NewRoaringBitmap()
+	for i := uint32(0); i < 5000; i++ {
+		r.Set(i * 2)
+	}
+	if r.containers[0].bitmap == nil {
+		t.Errorf("dense container is not a bitmap")
+	}
+	for i := uint32(0); i < 2000; i++ {
+		r.Reset(i * 2)
+	}
+	if r.containers[0].bitmap != nil {
+		t.Errorf("sparse container is not an array")
+	}
+	if r.Count() != 3000 || !r.Test(4000) || r.Test(4001) {
+		t.Errorf("container conversion lost values")
+	}
+
+	r.Flip(4001)
+	r.Flip(4000)
+	if !r.Test(4001) || r.Test(4000) {
+		t.Errorf("Flip() did not invert the values")
+	}
+}
+
+func TestRoaringBitmap_Marshal(t *testing.T) {
+	r, _ := randomRoaringPair(rand.New(rand.NewSource(2)))
+	data, err := r.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	var restored RoaringBitmap
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if !restored.Equal(&r) {
+		t.Errorf("restored set differs from the original")
+	}
+	if err := restored.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary() of truncated data error = %v, want %v", err, ErrInvalidData)
+	}
+
+	r.Clear()
+	if !r.IsEmpty() || r.Values().Length() != 0 {
+		t.Errorf("set not empty after Clear")
+	}
+}
+
+func TestRoaringBitmap_UnmarshalBinary_Invalid(t *testing.T) {
+	container := func(count uint32, kind byte, payload []byte) []byte {
+		data := marshalHeader(roaringBitmapTag, 1)
+		data = binary.BigEndian.AppendUint16(data, 0)
+		data = binary.BigEndian.AppendUint32(data, count)
+		return append(append(data, kind), payload...)
+	}
+	array := func(values ...uint16) []byte {
+		var data []byte
+		for _, value := range values {
+			data = binary.BigEndian.AppendUint16(data, value)
+		}
+		return data
+	}
+	bitmap := func(ones int) []byte {
+		words := make([]uint64, roaringWords)
+		for i := 0; i < ones; i++ {
+			words[i/64] |= 1 << (i % 64)
+		}
+		var data []byte
+		for _, word := range words {
+			data = binary.BigEndian.AppendUint64(data, word)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name  string
+		data  []byte
+		valid bool
+	}{
+		{name: "valid array", data: container(3, roaringArrayKind, array(1, 5, 9)), valid: true},
+		{name: "valid bitmap", data: container(5000, roaringBitmapKind, bitmap(5000)), valid: true},
+		{name: "unsorted array", data: container(3, roaringArrayKind, array(1, 9, 5))},
+		{name: "array with duplicates", data: container(3, roaringArrayKind, array(1, 5, 5))},
+		{name: "bitmap with a wrong count", data: container(6000, roaringBitmapKind, bitmap(5000))},
+		{name: "bitmap of an array size", data: container(100, roaringBitmapKind, bitmap(100))},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var r RoaringBitmap
+			err := r.UnmarshalBinary(tc.data)
+			if tc.valid && err != nil || !tc.valid && !errors.Is(err, ErrInvalidData) {
+				t.Errorf("UnmarshalBinary() error = %v, valid %v", err, tc.valid)
+			}
+		})
+	}
+}
+
+func TestBitSet_UnmarshalBinary_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "word count overflows the length", data: marshalHeader(bitSetTag, 1<<61)},
+		{name: "missing words", data: marshalHeader(bitSetTag, 2)},
+		{name: "extra bytes", data: append(marshalHeader(bitSetTag, 0), 1)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var b BitSet
+			if err := b.UnmarshalBinary(tc.data); !errors.Is(err, ErrInvalidData) {
+				t.Errorf("UnmarshalBinary() error = %v, want %v", err, ErrInvalidData)
+			}
+		})
+	}
+}
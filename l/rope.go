@@ -0,0 +1,280 @@
+package l
+
+import "slices"
+
+// ropeChunkSize is the largest number of items stored in one leaf of a Rope.
+const ropeChunkSize = 512
+
+// Rope is a sequence stored as a balanced tree of chunks. Unlike a List, it inserts and deletes items anywhere
+// in O(log n), and two ropes can be concatenated or a rope can be split in O(log n), copying at most one chunk.
+// The tree is kept balanced like an AVL tree, and adjacent small chunks are merged when the tree is joined.
+type Rope[T any] struct {
+	root *ropeNode[T]
+}
+
+// ropeNode is a leaf with items or an inner node with two children.
+type ropeNode[T any] struct {
+	avlLinks[*ropeNode[T]]
+	items  []T
+	length int
+}
+
+// NewRope creates a Rope with the provided items.
+//
+// Example usage:
+//
+//	r := NewRope([]rune("hello world")...)
+//	r.Insert(5, []rune(",")...)
+//	r.Delete(6, 7)
+//	string(r.Slice()) // "hello,world"
+func NewRope[T any](items ...T) Rope[T] {
+	return Rope[T]{root: ropeBuild(items)}
+}
+
+// Length returns the number of items in the rope.
+func (r *Rope[T]) Length() int {
+	return ropeLength(r.root)
+}
+
+// IsEmpty returns true if the rope has no items, false otherwise.
+func (r *Rope[T]) IsEmpty() bool {
+	return r.root == nil
+}
+
+// Clear removes all items from the rope.
+func (r *Rope[T]) Clear() {
+	r.root = nil
+}
+
+// Get returns a pointer to the item at the index. Modifying the value through the pointer modifies the item in
+// the rope, until the rope is changed. It panics if the index is out of range.
+func (r *Rope[T]) Get(index int) *T {
+	r.check(index, r.Length()-1)
+	node := r.root
+	for node.items == nil {
+		if index < node.left.length {
+			node = node.left
+		} else {
+			index -= node.left.length
+			node = node.right
+		}
+	}
+	return &node.items[index]
+}
+
+// Set replaces the item at the index. It panics if the index is out of range.
+func (r *Rope[T]) Set(index int, item T) {
+	*r.Get(index) = item
+}
+
+// Insert inserts the items before the item at the index. An index equal to Length appends the items.
+// It panics if the index is out of range.
+func (r *Rope[T]) Insert(index int, items ...T) {
+	r.check(index, r.Length())
+	if len(items) == 0 {
+		return
+	}
+	if r.insertIntoLeaf(r.root, index, items) {
+		return
+	}
+
+	left, right := ropeSplit(r.root, index)
+	r.root = ropeJoin(ropeJoin(left, ropeBuild(items)), right)
+}
+
+// Add appends the items to the end of the rope.
+func (r *Rope[T]) Add(items ...T) {
+	r.Insert(r.Length(), items...)
+}
+
+// Delete removes the items in the range [from, to). It panics if the range is out of bounds.
+func (r *Rope[T]) Delete(from, to int) {
+	r.check(from, r.Length())
+	r.check(to, r.Length())
+	if from >= to {
+		return
+	}
+
+	left, rest := ropeSplit(r.root, from)
+	_, right := ropeSplit(rest, to-from)
+	r.root = ropeJoin(left, right)
+}
+
+// Concat moves all items of other to the end of r, leaving other empty.
+func (r *Rope[T]) Concat(other *Rope[T]) {
+	r.root = ropeJoin(r.root, other.root)
+	other.root = nil
+}
+
+// Split moves the items from the index on into a new rope and returns it. The items before the index stay in r.
+// It panics if the index is out of range.
+func (r *Rope[T]) Split(index int) Rope[T] {
+	r.check(index, r.Length())
+	var right *ropeNode[T]
+	r.root, right = ropeSplit(r.root, index)
+	return Rope[T]{root: right}
+}
+
+// ForEach calls f with the index and value of every item in order.
+func (r *Rope[T]) ForEach(f func(index int, item T)) {
+	index := 0
+	ropeLeaves(r.root, func(items []T) bool {
+		for _, item := range items {
+			f(index, item)
+			index++
+		}
+		return true
+	})
+}
+
+// All returns a function that calls yield with every item of the rope in order, until yield returns false.
+func (r *Rope[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		ropeLeaves(r.root, func(items []T) bool {
+			for _, item := range items {
+				if !yield(item) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
+
+// Slice returns a slice with all items of the rope.
+func (r *Rope[T]) Slice() []T {
+	items := make([]T, 0, r.Length())
+	ropeLeaves(r.root, func(chunk []T) bool {
+		items = append(items, chunk...)
+		return true
+	})
+	return items
+}
+
+func (r *Rope[T]) check(index, limit int) {
+	if index < 0 || index > limit {
+		panic("l: Rope index out of range")
+	}
+}
+
+// insertIntoLeaf inserts the items directly into the leaf that holds the index if they fit into it.
+// It returns false if they do not fit and the tree has not been changed.
+func (r *Rope[T]) insertIntoLeaf(node *ropeNode[T], index int, items []T) bool {
+	if node == nil {
+		return false
+	}
+	if node.items != nil {
+		if len(node.items)+len(items) > ropeChunkSize {
+			return false
+		}
+		node.items = slices.Insert(node.items, index, items...)
+		node.length = len(node.items)
+		return true
+	}
+
+	var inserted bool
+	if index <= node.left.length {
+		inserted = r.insertIntoLeaf(node.left, index, items)
+	} else {
+		inserted = r.insertIntoLeaf(node.right, index-node.left.length, items)
+	}
+	if inserted {
+		node.length += len(items)
+	}
+	return inserted
+}
+
+// ropeBuild creates a balanced tree with leaves of at most ropeChunkSize items. The items are copied.
+func ropeBuild[T any](items []T) *ropeNode[T] {
+	if len(items) == 0 {
+		return nil
+	}
+	if len(items) <= ropeChunkSize {
+		return ropeLeaf(append([]T(nil), items...))
+	}
+
+	// The split point is aligned to the chunk size, so that all leaves but the last one are full.
+	chunks := (len(items) + ropeChunkSize - 1) / ropeChunkSize
+	mid := chunks / 2 * ropeChunkSize
+	return ropeInner(ropeBuild(items[:mid]), ropeBuild(items[mid:]))
+}
+
+// ropeSplit divides the tree into the first index items and the rest.
+func ropeSplit[T any](node *ropeNode[T], index int) (*ropeNode[T], *ropeNode[T]) {
+	switch {
+	case node == nil:
+		return nil, nil
+	case index <= 0:
+		return nil, node
+	case index >= node.length:
+		return node, nil
+	case node.items != nil:
+		return ropeLeaf(append([]T(nil), node.items[:index]...)), ropeLeaf(append([]T(nil), node.items[index:]...))
+	case index < node.left.length:
+		left, right := ropeSplit(node.left, index)
+		return left, ropeJoin(right, node.right)
+	default:
+		left, right := ropeSplit(node.right, index-node.left.length)
+		return ropeJoin(node.left, left), right
+	}
+}
+
+// ropeJoin concatenates two trees into a balanced tree.
+func ropeJoin[T any](left, right *ropeNode[T]) *ropeNode[T] {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case left.height > right.height+1:
+		left.right = ropeJoin(left.right, right)
+		return avlBalance(left)
+	case right.height > left.height+1:
+		right.left = ropeJoin(left, right.left)
+		return avlBalance(right)
+	case left.items != nil && right.items != nil && left.length+right.length <= ropeChunkSize:
+		left.items = append(left.items, right.items...)
+		left.length = len(left.items)
+		return left
+	default:
+		return ropeInner(left, right)
+	}
+}
+
+func ropeLeaf[T any](items []T) *ropeNode[T] {
+	return &ropeNode[T]{avlLinks: avlLinks[*ropeNode[T]]{height: 1}, items: items, length: len(items)}
+}
+
+func ropeInner[T any](left, right *ropeNode[T]) *ropeNode[T] {
+	node := &ropeNode[T]{avlLinks: avlLinks[*ropeNode[T]]{left: left, right: right}}
+	avlUpdate(node)
+	return node
+}
+
+// ropeLeaves calls f with the items of every leaf in order. It returns false if the iteration was stopped.
+func ropeLeaves[T any](node *ropeNode[T], f func(items []T) bool) bool {
+	switch {
+	case node == nil:
+		return true
+	case node.items != nil:
+		return f(node.items)
+	default:
+		return ropeLeaves(node.left, f) && ropeLeaves(node.right, f)
+	}
+}
+
+func ropeLength[T any](node *ropeNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.length
+}
+
+func (n *ropeNode[T]) links() *avlLinks[*ropeNode[T]] {
+	return &n.avlLinks
+}
+
+// update is only called for inner nodes, whose children are not nil.
+func (n *ropeNode[T]) update() {
+	n.length = n.left.length + n.right.length
+}
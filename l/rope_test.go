@@ -0,0 +1,123 @@
+package l
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// checkRope verifies the lengths, heights and balance of every node of the rope.
+func checkRope[T any](t *testing.T, node *ropeNode[T]) {
+	t.Helper()
+	if node == nil || node.items != nil {
+		if node != nil && (len(node.items) == 0 || node.length != len(node.items) || node.height != 1) {
+			t.Fatalf("invalid leaf: %d items, length %d, height %d", len(node.items), node.length, node.height)
+		}
+		return
+	}
+	checkRope(t, node.left)
+	checkRope(t, node.right)
+	if node.length != node.left.length+node.right.length || node.height != 1+max(node.left.height, node.right.height) {
+		t.Fatalf("invalid inner node: length %d, height %d", node.length, node.height)
+	}
+	if balance := node.left.height - node.right.height; balance < -1 || balance > 1 {
+		t.Fatalf("unbalanced node: %d", balance)
+	}
+}
+
+func TestRope_Edits(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(r *Rope[rune])
+		want string
+	}{
+		{name: "insert", edit: func(r *Rope[rune]) { r.Insert(5, []rune(",")...) }, want: "hello, world"},
+		{name: "insert at start", edit: func(r *Rope[rune]) { r.Insert(0, []rune(">> ")...) }, want: ">> hello world"},
+		{name: "add", edit: func(r *Rope[rune]) { r.Add('!') }, want: "hello world!"},
+		{name: "delete", edit: func(r *Rope[rune]) { r.Delete(5, 11) }, want: "hello"},
+		{name: "delete nothing", edit: func(r *Rope[rune]) { r.Delete(3, 3) }, want: "hello world"},
+		{name: "set", edit: func(r *Rope[rune]) { r.Set(0, 'H') }, want: "Hello world"},
+		{name: "split", edit: func(r *Rope[rune]) { r.Split(5) }, want: "hello"},
+		{name: "concat", edit: func(r *Rope[rune]) {
+			other := NewRope([]rune(" again")...)
+			r.Concat(&other)
+		}, want: "hello world again"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRope([]rune("hello world")...)
+			tc.edit(&r)
+			if got := string(r.Slice()); got != tc.want {
+				t.Errorf("rope = %q, want %q", got, tc.want)
+			}
+			if r.Length() != len(tc.want) {
+				t.Errorf("Length() = %d, want %d", r.Length(), len(tc.want))
+			}
+		})
+	}
+}
+
+func TestRope_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	r := NewRope[int]()
+	reference := []int{}
+
+	for i := 0; i < 3000; i++ {
+		switch op := rnd.Intn(10); {
+		case op < 5:
+			index := rnd.Intn(len(reference) + 1)
+			items := make([]int, rnd.Intn(700))
+			for j := range items {
+				items[j] = rnd.Int()
+			}
+			r.Insert(index, items...)
+			reference = append(reference[:index], append(items, reference[index:]...)...)
+		case op < 8:
+			from := rnd.Intn(len(reference) + 1)
+			to := from + rnd.Intn(len(reference)-from+1)/4
+			r.Delete(from, to)
+			reference = append(reference[:from], reference[to:]...)
+		default:
+			index := rnd.Intn(len(reference) + 1)
+			right := r.Split(index)
+			checkRope(t, r.root)
+			checkRope(t, right.root)
+			r.Concat(&right)
+		}
+		checkRope(t, r.root)
+		if r.Length() != len(reference) {
+			t.Fatalf("Length() = %d, want %d", r.Length(), len(reference))
+		}
+	}
+
+	if !reflect.DeepEqual(r.Slice(), reference) {
+		t.Fatalf("rope differs from the reference")
+	}
+	for i := 0; i < 100 && len(reference) > 0; i++ {
+		index := rnd.Intn(len(reference))
+		if *r.Get(index) != reference[index] {
+			t.Errorf("Get(%d) = %d, want %d", index, *r.Get(index), reference[index])
+		}
+	}
+	count := 0
+	r.ForEach(func(index int, item int) {
+		if item != reference[index] {
+			t.Fatalf("ForEach() item %d = %d, want %d", index, item, reference[index])
+		}
+		count++
+	})
+	if count != len(reference) {
+		t.Errorf("ForEach() visited %d items, want %d", count, len(reference))
+	}
+}
+
+func TestRope_OutOfRange(t *testing.T) {
+	r := NewRope(1, 2, 3)
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Insert() out of range did not panic")
+		}
+	}()
+	r.Insert(4, 1)
+}
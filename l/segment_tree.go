@@ -0,0 +1,250 @@
+package l
+
+// SegmentTree answers aggregate queries over ranges of a sequence, such as sums, minimums, maximums or greatest
+// common divisors. The aggregate is defined by an associative combine function and its identity element.
+// Set and Query run in O(log n).
+type SegmentTree[T any] struct {
+	combine  func(a, b T) T
+	identity T
+	// nodes holds the tree in an array: the leaves are at n..2n-1 and node i combines the nodes 2i and 2i+1.
+	nodes []T
+	n     int
+}
+
+// NewSegmentTree creates a SegmentTree over the items. The combine function must be associative, and combining
+// any value with identity must return the value.
+//
+// Example usage:
+//
+//	t := NewSegmentTree(func(a, b int) int { return min(a, b) }, math.MaxInt, 5, 2, 8, 1)
+//	t.Query(0, 3) // 2
+//	t.Set(1, 7)
+//	t.Query(0, 3) // 5
+func NewSegmentTree[T any](combine func(a, b T) T, identity T, items ...T) SegmentTree[T] {
+	n := len(items)
+	t := SegmentTree[T]{combine: combine, identity: identity, nodes: make([]T, 2*n), n: n}
+	copy(t.nodes[n:], items)
+	for i := n - 1; i > 0; i-- {
+		t.nodes[i] = combine(t.nodes[2*i], t.nodes[2*i+1])
+	}
+	return t
+}
+
+// Length returns the number of items in the tree.
+func (t *SegmentTree[T]) Length() int {
+	return t.n
+}
+
+// Get returns the item at the index. It panics if the index is out of range.
+func (t *SegmentTree[T]) Get(index int) T {
+	t.check(index)
+	return t.nodes[t.n+index]
+}
+
+// Set replaces the item at the index. It panics if the index is out of range.
+func (t *SegmentTree[T]) Set(index int, item T) {
+	t.check(index)
+	i := t.n + index
+	t.nodes[i] = item
+	for i /= 2; i > 0; i /= 2 {
+		t.nodes[i] = t.combine(t.nodes[2*i], t.nodes[2*i+1])
+	}
+}
+
+// Query returns the combination of the items in the range [from, to), or the identity if the range is empty.
+// The bounds are clamped to the items of the tree.
+func (t *SegmentTree[T]) Query(from, to int) T {
+	// The left and right results are kept apart because combine does not have to be commutative.
+	left, right := t.identity, t.identity
+	for from, to = max(from, 0)+t.n, min(to, t.n)+t.n; from < to; from, to = from/2, to/2 {
+		if from%2 == 1 {
+			left = t.combine(left, t.nodes[from])
+			from++
+		}
+		if to%2 == 1 {
+			to--
+			right = t.combine(t.nodes[to], right)
+		}
+	}
+	return t.combine(left, right)
+}
+
+// Slice returns a slice with all items of the tree.
+func (t *SegmentTree[T]) Slice() []T {
+	return append([]T{}, t.nodes[t.n:]...)
+}
+
+func (t *SegmentTree[T]) check(index int) {
+	if index < 0 || index >= t.n {
+		panic("l: SegmentTree index out of range")
+	}
+}
+
+// LazySegmentTree is a SegmentTree that also updates whole ranges in O(log n). Updates of type U are applied
+// to the aggregates lazily: they are stored at the top of a range and pushed down only when a query needs it.
+type LazySegmentTree[T any, U any] struct {
+	combine  func(a, b T) T
+	identity T
+	apply    func(aggregate T, update U, length int) T
+	compose  func(previous, next U) U
+	nodes    []T
+	pending  []U
+	updated  []bool
+	n        int
+}
+
+// NewLazySegmentTree creates a LazySegmentTree over the items. The combine function and identity define the
+// aggregate as in NewSegmentTree. The apply function returns the aggregate of a range of the given length after
+// the update, and compose merges two updates into one that has the effect of applying previous and then next.
+//
+// Example usage, range addition with range sums:
+//
+//	t := NewLazySegmentTree(
+//	    func(a, b int) int { return a + b }, 0,
+//	    func(sum, add, length int) int { return sum + add*length },
+//	    func(previous, next int) int { return previous + next },
+//	    1, 2, 3, 4)
+//	t.Update(1, 3, 10) // 1, 12, 13, 4
+//	t.Query(0, 4) // 30
+func NewLazySegmentTree[T any, U any](
+	combine func(a, b T) T,
+	identity T,
+	apply func(aggregate T, update U, length int) T,
+	compose func(previous, next U) U,
+	items ...T,
+) LazySegmentTree[T, U] {
+	n := len(items)
+	t := LazySegmentTree[T, U]{
+		combine:  combine,
+		identity: identity,
+		apply:    apply,
+		compose:  compose,
+		nodes:    make([]T, 4*n),
+		pending:  make([]U, 4*n),
+		updated:  make([]bool, 4*n),
+		n:        n,
+	}
+	if n > 0 {
+		t.build(1, 0, n, items)
+	}
+	return t
+}
+
+// Length returns the number of items in the tree.
+func (t *LazySegmentTree[T, U]) Length() int {
+	return t.n
+}
+
+// Get returns the item at the index with all updates applied. It panics if the index is out of range.
+func (t *LazySegmentTree[T, U]) Get(index int) T {
+	if index < 0 || index >= t.n {
+		panic("l: LazySegmentTree index out of range")
+	}
+	return t.Query(index, index+1)
+}
+
+// Set replaces the item at the index. It panics if the index is out of range.
+func (t *LazySegmentTree[T, U]) Set(index int, item T) {
+	if index < 0 || index >= t.n {
+		panic("l: LazySegmentTree index out of range")
+	}
+	t.set(1, 0, t.n, index, item)
+}
+
+// Query returns the combination of the items in the range [from, to), or the identity if the range is empty.
+// The bounds are clamped to the items of the tree.
+func (t *LazySegmentTree[T, U]) Query(from, to int) T {
+	from, to = max(from, 0), min(to, t.n)
+	if from >= to {
+		return t.identity
+	}
+	return t.query(1, 0, t.n, from, to)
+}
+
+// Update applies the update to every item in the range [from, to). The bounds are clamped to the items of the tree.
+func (t *LazySegmentTree[T, U]) Update(from, to int, update U) {
+	from, to = max(from, 0), min(to, t.n)
+	if from < to {
+		t.update(1, 0, t.n, from, to, update)
+	}
+}
+
+func (t *LazySegmentTree[T, U]) build(node, lo, hi int, items []T) {
+	if hi-lo == 1 {
+		t.nodes[node] = items[lo]
+		return
+	}
+	mid := (lo + hi) / 2
+	t.build(2*node, lo, mid, items)
+	t.build(2*node+1, mid, hi, items)
+	t.nodes[node] = t.combine(t.nodes[2*node], t.nodes[2*node+1])
+}
+
+// mark applies the update to the aggregate of the node covering [lo, hi) and records it for the children.
+func (t *LazySegmentTree[T, U]) mark(node, lo, hi int, update U) {
+	t.nodes[node] = t.apply(t.nodes[node], update, hi-lo)
+	if hi-lo > 1 {
+		if t.updated[node] {
+			t.pending[node] = t.compose(t.pending[node], update)
+		} else {
+			t.pending[node], t.updated[node] = update, true
+		}
+	}
+}
+
+// push moves the pending update of the node covering [lo, hi) to its children.
+func (t *LazySegmentTree[T, U]) push(node, lo, hi int) {
+	if !t.updated[node] {
+		return
+	}
+	mid := (lo + hi) / 2
+	t.mark(2*node, lo, mid, t.pending[node])
+	t.mark(2*node+1, mid, hi, t.pending[node])
+	var zero U
+	t.pending[node], t.updated[node] = zero, false
+}
+
+func (t *LazySegmentTree[T, U]) set(node, lo, hi, index int, item T) {
+	if hi-lo == 1 {
+		t.nodes[node] = item
+		return
+	}
+	t.push(node, lo, hi)
+	if mid := (lo + hi) / 2; index < mid {
+		t.set(2*node, lo, mid, index, item)
+	} else {
+		t.set(2*node+1, mid, hi, index, item)
+	}
+	t.nodes[node] = t.combine(t.nodes[2*node], t.nodes[2*node+1])
+}
+
+func (t *LazySegmentTree[T, U]) query(node, lo, hi, from, to int) T {
+	if from <= lo && hi <= to {
+		return t.nodes[node]
+	}
+	t.push(node, lo, hi)
+	mid := (lo + hi) / 2
+	switch {
+	case to <= mid:
+		return t.query(2*node, lo, mid, from, to)
+	case from >= mid:
+		return t.query(2*node+1, mid, hi, from, to)
+	default:
+		return t.combine(t.query(2*node, lo, mid, from, to), t.query(2*node+1, mid, hi, from, to))
+	}
+}
+
+func (t *LazySegmentTree[T, U]) update(node, lo, hi, from, to int, update U) {
+	if to <= lo || hi <= from {
+		return
+	}
+	if from <= lo && hi <= to {
+		t.mark(node, lo, hi, update)
+		return
+	}
+	t.push(node, lo, hi)
+	mid := (lo + hi) / 2
+	t.update(2*node, lo, mid, from, to, update)
+	t.update(2*node+1, mid, hi, from, to, update)
+	t.nodes[node] = t.combine(t.nodes[2*node], t.nodes[2*node+1])
+}
@@ -0,0 +1,141 @@
+package l
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func TestSegmentTree_Query(t *testing.T) {
+	items := []int{12, 18, 6, 30, 7, 14, 21}
+
+	tests := []struct {
+		name     string
+		combine  func(a, b int) int
+		identity int
+		from, to int
+		want     int
+	}{
+		{name: "sum", combine: func(a, b int) int { return a + b }, from: 1, to: 4, want: 54},
+		{name: "min", combine: func(a, b int) int { return min(a, b) }, identity: math.MaxInt, from: 3, to: 7, want: 7},
+		{name: "max", combine: func(a, b int) int { return max(a, b) }, identity: math.MinInt, from: 0, to: 3, want: 18},
+		{name: "gcd", combine: gcd, from: 0, to: 4, want: 6},
+		{name: "gcd of multiples of seven", combine: gcd, from: 4, to: 7, want: 7},
+		{name: "empty range", combine: func(a, b int) int { return a + b }, from: 3, to: 3, want: 0},
+		{name: "clamped range", combine: func(a, b int) int { return a + b }, from: -5, to: 50, want: 108},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := NewSegmentTree(tc.combine, tc.identity, items...)
+			if got := tree.Query(tc.from, tc.to); got != tc.want {
+				t.Errorf("Query(%d, %d) = %d, want %d", tc.from, tc.to, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSegmentTree_NonCommutative(t *testing.T) {
+	tree := NewSegmentTree(func(a, b string) string { return a + b }, "", "a", "b", "c", "d", "e")
+	tree.Set(2, "C")
+
+	for from := 0; from <= 5; from++ {
+		for to := from; to <= 5; to++ {
+			want := ""
+			for _, item := range tree.Slice()[from:to] {
+				want += item
+			}
+			if got := tree.Query(from, to); got != want {
+				t.Errorf("Query(%d, %d) = %q, want %q", from, to, got, want)
+			}
+		}
+	}
+	if tree.Get(2) != "C" || tree.Length() != 5 {
+		t.Errorf("Get(2) = %q, Length() = %d", tree.Get(2), tree.Length())
+	}
+}
+
+func TestLazySegmentTree_Random(t *testing.T) {
+	// Items are summed, and an update either adds to or assigns a value to the items.
+	type update struct {
+		assign bool
+		value  int
+	}
+	apply := func(sum int, u update, length int) int {
+		if u.assign {
+			return u.value * length
+		}
+		return sum + u.value*length
+	}
+	compose := func(previous, next update) update {
+		if next.assign {
+			return next
+		}
+		return update{assign: previous.assign, value: previous.value + next.value}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	reference := make([]int, 37)
+	for i := range reference {
+		reference[i] = rnd.Intn(100)
+	}
+	tree := NewLazySegmentTree(func(a, b int) int { return a + b }, 0, apply, compose, reference...)
+
+	for i := 0; i < 2000; i++ {
+		from := rnd.Intn(len(reference))
+		to := from + rnd.Intn(len(reference)-from+1)
+		switch rnd.Intn(3) {
+		case 0:
+			u := update{assign: rnd.Intn(2) == 0, value: rnd.Intn(21) - 10}
+			tree.Update(from, to, u)
+			for j := from; j < to; j++ {
+				reference[j] = apply(reference[j], u, 1)
+			}
+		case 1:
+			value := rnd.Intn(100)
+			tree.Set(from%len(reference), value)
+			reference[from%len(reference)] = value
+		default:
+			want := 0
+			for _, item := range reference[from:to] {
+				want += item
+			}
+			if got := tree.Query(from, to); got != want {
+				t.Fatalf("Query(%d, %d) = %d, want %d", from, to, got, want)
+			}
+		}
+	}
+
+	for i, item := range reference {
+		if got := tree.Get(i); got != item {
+			t.Errorf("Get(%d) = %d, want %d", i, got, item)
+		}
+	}
+}
+
+func TestSegmentTree_Empty(t *testing.T) {
+	tree := NewSegmentTree(func(a, b int) int { return a + b }, 0)
+	lazy := NewLazySegmentTree(
+		func(a, b int) int { return a + b }, 0,
+		func(sum, add, length int) int { return sum + add*length },
+		func(previous, next int) int { return previous + next })
+	lazy.Update(0, 10, 5)
+
+	if tree.Query(0, 10) != 0 || lazy.Query(0, 10) != 0 || tree.Length() != 0 || lazy.Length() != 0 {
+		t.Errorf("query of an empty tree is not the identity")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Set() out of range did not panic")
+		}
+	}()
+	tree.Set(0, 1)
+}
@@ -0,0 +1,245 @@
+package l
+
+import (
+	"cmp"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+const skipListMaxLevel = 32
+
+// SkipList is an ordered map based on a skip list: a hierarchy of linked lists in which every level skips over
+// a random subset of the nodes of the level below. Put, Get and Delete run in O(log n) expected time and the
+// entries can be iterated in key order.
+//
+// A SkipList is safe for concurrent use. Writers are serialized by a mutex, while readers never take a lock:
+// nodes are linked and unlinked with atomic pointer updates in an order that keeps every list consistent for
+// readers, so reads never block and are never blocked by writes.
+type SkipList[K any, V any] struct {
+	mu      sync.Mutex
+	compare func(a, b K) int
+	head    *skipNode[K, V]
+	level   atomic.Int32
+	length  atomic.Int64
+}
+
+type skipNode[K any, V any] struct {
+	key     K
+	value   atomic.Pointer[V]
+	deleted atomic.Bool
+	next    []atomic.Pointer[skipNode[K, V]]
+}
+
+// NewSkipList creates an empty SkipList that orders the keys by their natural order.
+func NewSkipList[K cmp.Ordered, V any]() *SkipList[K, V] {
+	return NewSkipListFunc[K, V](cmp.Compare[K])
+}
+
+// NewSkipListFunc creates an empty SkipList that orders the keys with the given comparator.
+// The comparator returns a negative number if a < b, zero if a == b and a positive number if a > b.
+func NewSkipListFunc[K any, V any](compare func(a, b K) int) *SkipList[K, V] {
+	s := &SkipList[K, V]{
+		compare: compare,
+		head:    &skipNode[K, V]{next: make([]atomic.Pointer[skipNode[K, V]], skipListMaxLevel)},
+	}
+	s.level.Store(1)
+	return s
+}
+
+// Put stores the value under the given key, replacing the previous value if the key already exists.
+// It returns true if the key was not in the list before.
+func (s *SkipList[K, V]) Put(key K, value V) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var preds [skipListMaxLevel]*skipNode[K, V]
+	node := s.findPredecessors(key, &preds)
+	if node != nil {
+		node.value.Store(&value)
+		return false
+	}
+
+	level := s.randomLevel()
+	if current := int(s.level.Load()); level > current {
+		for i := current; i < level; i++ {
+			preds[i] = s.head
+		}
+		s.level.Store(int32(level))
+	}
+
+	node = &skipNode[K, V]{key: key, next: make([]atomic.Pointer[skipNode[K, V]], level)}
+	node.value.Store(&value)
+	for i := 0; i < level; i++ {
+		node.next[i].Store(preds[i].next[i].Load())
+	}
+	// The node is linked bottom-up, so a reader that finds it on any level also finds it on the levels below.
+	for i := 0; i < level; i++ {
+		preds[i].next[i].Store(node)
+	}
+	s.length.Add(1)
+	return true
+}
+
+// Get returns a pointer to the value stored under the given key. If the key is not in the list, it returns nil.
+// The value is shared with concurrent readers and must not be modified through the pointer; use Put instead.
+func (s *SkipList[K, V]) Get(key K) *V {
+	node := s.find(key)
+	if node == nil {
+		return nil
+	}
+	return node.value.Load()
+}
+
+// Contains returns true if the key is in the list.
+func (s *SkipList[K, V]) Contains(key K) bool {
+	return s.find(key) != nil
+}
+
+// Delete removes the key from the list. It returns true if the key was in the list.
+func (s *SkipList[K, V]) Delete(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var preds [skipListMaxLevel]*skipNode[K, V]
+	node := s.findPredecessors(key, &preds)
+	if node == nil {
+		return false
+	}
+
+	node.deleted.Store(true)
+	// The node is unlinked top-down and keeps its own links, so readers standing on it can continue their walk.
+	for i := len(node.next) - 1; i >= 0; i-- {
+		preds[i].next[i].Store(node.next[i].Load())
+	}
+	s.length.Add(-1)
+	return true
+}
+
+// Length returns the number of entries in the list.
+func (s *SkipList[K, V]) Length() int {
+	return int(s.length.Load())
+}
+
+// IsEmpty returns true if the list has no entries, false otherwise.
+func (s *SkipList[K, V]) IsEmpty() bool {
+	return s.Length() == 0
+}
+
+// Clear removes all entries from the list.
+func (s *SkipList[K, V]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.head.next {
+		s.head.next[i].Store(nil)
+	}
+	s.level.Store(1)
+	s.length.Store(0)
+}
+
+// ForEach calls f for every entry of the list in ascending key order.
+func (s *SkipList[K, V]) ForEach(f func(key K, value V)) {
+	s.Ascend(func(key K, value V) bool {
+		f(key, value)
+		return true
+	})
+}
+
+// Ascend calls f for every entry of the list in ascending key order. The iteration stops when f returns false.
+// Entries that are added or removed during the iteration may or may not be visited.
+func (s *SkipList[K, V]) Ascend(f func(key K, value V) bool) {
+	s.walk(s.head.next[0].Load(), nil, f)
+}
+
+// Range calls f in ascending key order for every entry whose key is in the range [from, to).
+// The iteration stops when f returns false.
+func (s *SkipList[K, V]) Range(from, to K, f func(key K, value V) bool) {
+	node := s.head
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		for next := node.next[i].Load(); next != nil && s.compare(next.key, from) < 0; next = node.next[i].Load() {
+			node = next
+		}
+	}
+	s.walk(node.next[0].Load(), &to, f)
+}
+
+// All returns a function that calls yield with every entry of the list in ascending key order,
+// until yield returns false.
+func (s *SkipList[K, V]) All() func(yield func(Entry[K, V]) bool) {
+	return func(yield func(Entry[K, V]) bool) {
+		s.Ascend(func(key K, value V) bool {
+			return yield(Entry[K, V]{Key: key, Value: value})
+		})
+	}
+}
+
+// Keys returns a List with all keys of the list in ascending order.
+func (s *SkipList[K, V]) Keys() *List[K] {
+	keys := NewList[K]()
+	s.ForEach(func(key K, _ V) { keys.Add(key) })
+	return &keys
+}
+
+// find returns the live node with the given key or nil.
+func (s *SkipList[K, V]) find(key K) *skipNode[K, V] {
+	node := s.head
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		for next := node.next[i].Load(); next != nil; next = node.next[i].Load() {
+			c := s.compare(next.key, key)
+			if c > 0 {
+				break
+			}
+			if c == 0 {
+				if next.deleted.Load() {
+					return nil
+				}
+				return next
+			}
+			node = next
+		}
+	}
+	return nil
+}
+
+// findPredecessors fills preds with the last node before the key on every level and returns the node with the key,
+// if there is one. It must be called with the writer lock held.
+func (s *SkipList[K, V]) findPredecessors(key K, preds *[skipListMaxLevel]*skipNode[K, V]) *skipNode[K, V] {
+	node := s.head
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		for next := node.next[i].Load(); next != nil && s.compare(next.key, key) < 0; next = node.next[i].Load() {
+			node = next
+		}
+		preds[i] = node
+	}
+
+	next := node.next[0].Load()
+	if next != nil && s.compare(next.key, key) == 0 {
+		return next
+	}
+	return nil
+}
+
+// walk visits the live nodes on the bottom level starting at node, until the key reaches to.
+func (s *SkipList[K, V]) walk(node *skipNode[K, V], to *K, f func(K, V) bool) {
+	for ; node != nil; node = node.next[0].Load() {
+		if to != nil && s.compare(node.key, *to) >= 0 {
+			return
+		}
+		if node.deleted.Load() {
+			continue
+		}
+		if !f(node.key, *node.value.Load()) {
+			return
+		}
+	}
+}
+
+// randomLevel returns the level of a new node. Every level is kept with probability 1/4.
+func (s *SkipList[K, V]) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.Int63()&3 == 0 {
+		level++
+	}
+	return level
+}
@@ -0,0 +1,150 @@
+package l
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestSkipList_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	s := NewSkipList[int, int]()
+	expected := map[int]int{}
+
+	for i := 0; i < 5000; i++ {
+		key := rnd.Intn(500)
+		_, exists := expected[key]
+		if rnd.Intn(3) == 0 {
+			if got := s.Delete(key); got != exists {
+				t.Fatalf("Delete(%d) = %v, want %v", key, got, exists)
+			}
+			delete(expected, key)
+		} else {
+			if got := s.Put(key, i); got == exists {
+				t.Fatalf("Put(%d) = %v, want %v", key, got, !exists)
+			}
+			expected[key] = i
+		}
+	}
+
+	keys := make([]int, 0, len(expected))
+	for key, value := range expected {
+		keys = append(keys, key)
+		if got := s.Get(key); got == nil || *got != value {
+			t.Fatalf("Get(%d) = %v, want %d", key, got, value)
+		}
+	}
+	sort.Ints(keys)
+	if got := s.Keys().Slice(); !reflect.DeepEqual(got, keys) {
+		t.Errorf("Keys() = %v, want %v", got, keys)
+	}
+	if s.Length() != len(keys) {
+		t.Errorf("Length() = %d, want %d", s.Length(), len(keys))
+	}
+	if s.Contains(-1) {
+		t.Errorf("Contains(-1) = true, want false")
+	}
+}
+
+func TestSkipList_Range(t *testing.T) {
+	s := NewSkipList[int, string]()
+	for _, key := range []int{50, 10, 40, 20, 30} {
+		s.Put(key, "")
+	}
+
+	tests := []struct {
+		name string
+		from int
+		to   int
+		stop int
+		want []int
+	}{
+		{name: "inner range", from: 20, to: 40, want: []int{20, 30}},
+		{name: "bounds between keys", from: 15, to: 45, want: []int{20, 30, 40}},
+		{name: "whole list", from: 0, to: 100, want: []int{10, 20, 30, 40, 50}},
+		{name: "empty range", from: 41, to: 49, want: []int{}},
+		{name: "stopped early", from: 0, to: 100, stop: 2, want: []int{10, 20}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []int{}
+			s.Range(tc.from, tc.to, func(key int, _ string) bool {
+				got = append(got, key)
+				return tc.stop == 0 || len(got) < tc.stop
+			})
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Range(%d, %d) = %v, want %v", tc.from, tc.to, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSkipList_Clear(t *testing.T) {
+	s := NewSkipListFunc[string, int](func(a, b string) int { return len(a) - len(b) })
+	s.Put("aa", 2)
+	s.Put("b", 1)
+	s.Put("c", 3)
+	if got := s.Keys().Slice(); !reflect.DeepEqual(got, []string{"b", "aa"}) {
+		t.Errorf("Keys() = %v, want [b aa]", got)
+	}
+	if got := s.Get("x"); got == nil || *got != 3 {
+		t.Errorf("Get(x) = %v, want 3", got)
+	}
+
+	s.Clear()
+	if !s.IsEmpty() || s.Contains("b") {
+		t.Errorf("list not empty after Clear")
+	}
+}
+
+func TestSkipList_Concurrent(t *testing.T) {
+	const writers, readers, keys = 4, 4, 1000
+
+	s := NewSkipList[int, int]()
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < keys; i += writers {
+				s.Put(i, i)
+				if i%3 == 0 {
+					s.Delete(i)
+				}
+			}
+		}(w)
+	}
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < keys; i++ {
+				if value := s.Get(i); value != nil && *value != i {
+					t.Errorf("Get(%d) = %d", i, *value)
+				}
+				previous := -1
+				s.Range(i, i+10, func(key, _ int) bool {
+					if key <= previous {
+						t.Errorf("Range() returned %d after %d", key, previous)
+					}
+					previous = key
+					return true
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := 0
+	for i := 0; i < keys; i++ {
+		if i%3 != 0 {
+			want++
+		}
+	}
+	if s.Length() != want {
+		t.Errorf("Length() = %d, want %d", s.Length(), want)
+	}
+}
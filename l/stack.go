@@ -53,3 +53,25 @@ func (s *Stack[T]) Pop() *T {
 func (s *Stack[T]) Push(item T) {
 	s.items = append(s.items, item)
 }
+
+// IsEmpty returns true if the stack has no items, false otherwise.
+func (s *Stack[T]) IsEmpty() bool {
+	return len(s.items) == 0
+}
+
+// Clear removes all items from the stack.
+func (s *Stack[T]) Clear() {
+	s.items = nil
+}
+
+// All returns a function that calls yield with every item of the stack from the top to the bottom,
+// until yield returns false. The items are not removed.
+func (s *Stack[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for i := len(s.items) - 1; i >= 0; i-- {
+			if !yield(s.items[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package l
+
+// SubList is a live view of the items in the range [from, to) of a List. Reads and writes go through to the
+// List without copying. The view is fail-fast: once the List is structurally modified, that is items are added
+// or removed other than through the view, every use of the view panics with a ConcurrentModificationError instead
+// of returning stale data.
+type SubList[T any] struct {
+	parent   *List[T]
+	from     int
+	to       int
+	modCount int
+}
+
+// SubList returns a view of the items in the range [from, to). It panics if the range is out of bounds.
+//
+// Example usage:
+//
+//	list := NewList(1, 2, 3, 4, 5)
+//	view := list.SubList(1, 4)
+//	view.Set(0, 20)
+//	list.Slice() // [1, 20, 3, 4, 5]
+//	list.Add(6)
+//	view.Length() // panics, the list was modified
+func (l *List[T]) SubList(from, to int) SubList[T] {
+	checkRange(from, to, len(l.items))
+	return SubList[T]{parent: l, from: from, to: to, modCount: l.modCount}
+}
+
+// Range returns a new List with a copy of the items in the range [from, to). It panics if the range is out
+// of bounds.
+func (l *List[T]) Range(from, to int) *List[T] {
+	checkRange(from, to, len(l.items))
+	list := NewList(append([]T(nil), l.items[from:to]...)...)
+	return &list
+}
+
+// Head returns a new List with a copy of the first n items. If the list has fewer items, all are copied.
+func (l *List[T]) Head(n int) *List[T] {
+	return l.Range(0, max(0, min(n, len(l.items))))
+}
+
+// Tail returns a new List with a copy of the last n items. If the list has fewer items, all are copied.
+func (l *List[T]) Tail(n int) *List[T] {
+	return l.Range(len(l.items)-max(0, min(n, len(l.items))), len(l.items))
+}
+
+// Drop returns a new List with a copy of all items except the first n.
+func (l *List[T]) Drop(n int) *List[T] {
+	return l.Range(max(0, min(n, len(l.items))), len(l.items))
+}
+
+// Length returns the number of items in the view.
+func (s *SubList[T]) Length() int {
+	s.check()
+	return s.to - s.from
+}
+
+// IsEmpty returns true if the view has no items, false otherwise.
+func (s *SubList[T]) IsEmpty() bool {
+	return s.Length() == 0
+}
+
+// Get returns a pointer to the item at the index of the view. Modifying the value through the pointer modifies
+// the item in the List. It panics if the index is out of range.
+func (s *SubList[T]) Get(index int) *T {
+	s.check()
+	if index < 0 || index >= s.to-s.from {
+		panic("l: SubList index out of range")
+	}
+	return &s.parent.items[s.from+index]
+}
+
+// Set replaces the item at the index of the view. It panics if the index is out of range.
+func (s *SubList[T]) Set(index int, item T) {
+	*s.Get(index) = item
+}
+
+// Slice returns the items of the view as a slice that shares its memory with the List.
+// Its capacity ends with the view, so appending to it never overwrites the items of the List after the view.
+func (s *SubList[T]) Slice() []T {
+	s.check()
+	return s.parent.items[s.from:s.to:s.to]
+}
+
+// ForEach calls f with the index in the view and the value of every item in order.
+// Adding or removing items of the List from f panics with a ConcurrentModificationError.
+func (s *SubList[T]) ForEach(f func(index int, item T)) {
+	defer s.parent.iterate()()
+	for i, item := range s.Slice() {
+		f(i, item)
+	}
+}
+
+// All returns a function that calls yield with every item of the view in order, until yield returns false.
+// Adding or removing items of the List from yield panics with a ConcurrentModificationError.
+func (s *SubList[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		defer s.parent.iterate()()
+		for _, item := range s.Slice() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// SubList returns a view of the items in the range [from, to) of this view. It panics if the range is out
+// of bounds.
+func (s *SubList[T]) SubList(from, to int) SubList[T] {
+	checkRange(from, to, s.Length())
+	return SubList[T]{parent: s.parent, from: s.from + from, to: s.from + to, modCount: s.modCount}
+}
+
+// Clear removes the items of the view from the List. The view stays valid and becomes empty, but as a structural
+// modification of the List, it invalidates all other views of the List.
+func (s *SubList[T]) Clear() {
+	s.check()
+	if s.from == s.to {
+		return
+	}
+
+	s.parent.modify("SubList.Clear")
+	items := s.parent.items
+	s.parent.items = append(items[:s.from], items[s.to:]...)
+	// The vacated tail is zeroed so the backing array does not keep the removed items reachable.
+	clear(items[len(s.parent.items):])
+	s.modCount = s.parent.modCount
+	s.to = s.from
+}
+
+func (s *SubList[T]) check() {
+	if s.parent.modCount != s.modCount {
+		panic(&ConcurrentModificationError{Operation: "SubList"})
+	}
+}
+
+func checkRange(from, to, length int) {
+	if from < 0 || to > length || from > to {
+		panic("l: range out of bounds")
+	}
+}
@@ -0,0 +1,177 @@
+package l
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestList_SubList(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5)
+	view := list.SubList(1, 4)
+
+	if got := view.Slice(); !reflect.DeepEqual(got, []int{2, 3, 4}) {
+		t.Errorf("Slice() = %v, want [2 3 4]", got)
+	}
+	if view.Length() != 3 || view.IsEmpty() {
+		t.Errorf("Length() = %d, want 3", view.Length())
+	}
+
+	// Writes through the view change the list, and writes to the list are visible in the view.
+	view.Set(0, 20)
+	*view.Get(2) = 40
+	*list.Get(2) = 30
+	if got := list.Slice(); !reflect.DeepEqual(got, []int{1, 20, 30, 40, 5}) {
+		t.Errorf("list.Slice() = %v, want [1 20 30 40 5]", got)
+	}
+	if got := ToList[int](&view).Slice(); !reflect.DeepEqual(got, []int{20, 30, 40}) {
+		t.Errorf("All() = %v, want [20 30 40]", got)
+	}
+
+	// Appending to the slice of the view must not overwrite the item after the view.
+	_ = append(view.Slice(), 99)
+	if *list.Get(4) != 5 {
+		t.Errorf("append to the view slice overwrote the list: %v", list.Slice())
+	}
+
+	nested := view.SubList(1, 3)
+	if got := nested.Slice(); !reflect.DeepEqual(got, []int{30, 40}) {
+		t.Errorf("nested Slice() = %v, want [30 40]", got)
+	}
+
+	indexes := []int{}
+	nested.ForEach(func(index int, _ int) {
+		indexes = append(indexes, index)
+	})
+	if !reflect.DeepEqual(indexes, []int{0, 1}) {
+		t.Errorf("ForEach() indexes = %v, want [0 1]", indexes)
+	}
+}
+
+func TestSubList_Clear(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5)
+	view := list.SubList(1, 3)
+	other := list.SubList(0, 2)
+
+	view.Clear()
+	if got := list.Slice(); !reflect.DeepEqual(got, []int{1, 4, 5}) {
+		t.Errorf("list.Slice() = %v, want [1 4 5]", got)
+	}
+	if !view.IsEmpty() {
+		t.Errorf("view not empty after Clear")
+	}
+	assertPanics(t, "other view after Clear", func() { other.Length() })
+}
+
+func TestSubList_Clear_ZeroesTail(t *testing.T) {
+	a, b, c, d := 1, 2, 3, 4
+	list := NewList(&a, &b, &c, &d)
+	backing := list.Slice()
+
+	view := list.SubList(1, 3)
+	view.Clear()
+	if got := list.Slice(); !reflect.DeepEqual(got, []*int{&a, &d}) {
+		t.Errorf("list.Slice() = %v, want [%p %p]", got, &a, &d)
+	}
+	// The removed items must not stay reachable through the backing array.
+	if tail := backing[2:4]; tail[0] != nil || tail[1] != nil {
+		t.Errorf("vacated tail = %v, want [<nil> <nil>]", tail)
+	}
+}
+
+func TestSubList_Invalidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(list *List[int])
+		valid  bool
+	}{
+		{name: "set keeps the view valid", modify: func(list *List[int]) { *list.Get(0) = 10 }, valid: true},
+		{name: "remove of a missing index keeps the view valid", modify: func(list *List[int]) { list.Remove(10) }, valid: true},
+		{name: "add", modify: func(list *List[int]) { list.Add(6) }},
+		{name: "insert", modify: func(list *List[int]) { list.Insert(0, 0) }},
+		{name: "remove", modify: func(list *List[int]) { list.Remove(4) }},
+		{name: "clear", modify: func(list *List[int]) { list.Clear() }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			list := NewList(1, 2, 3, 4, 5)
+			view := list.SubList(1, 3)
+			tc.modify(&list)
+
+			if tc.valid {
+				if view.Length() != 2 {
+					t.Errorf("Length() = %d, want 2", view.Length())
+				}
+				return
+			}
+			assertPanics(t, "Length", func() { view.Length() })
+			assertPanics(t, "Get", func() { view.Get(0) })
+			assertPanics(t, "Slice", func() { view.Slice() })
+			assertPanics(t, "Clear", func() { view.Clear() })
+		})
+	}
+}
+
+func TestSubList_Bounds(t *testing.T) {
+	list := NewList(1, 2, 3)
+	view := list.SubList(1, 2)
+
+	assertPanics(t, "negative from", func() { list.SubList(-1, 2) })
+	assertPanics(t, "to after the end", func() { list.SubList(0, 4) })
+	assertPanics(t, "from after to", func() { list.SubList(2, 1) })
+	assertPanics(t, "nested to after the end of the view", func() { view.SubList(0, 2) })
+	assertPanics(t, "index after the end of the view", func() { view.Get(1) })
+	assertPanics(t, "negative index", func() { view.Set(-1, 0) })
+	assertPanics(t, "range out of bounds", func() { list.Range(1, 4) })
+
+	empty := list.SubList(3, 3)
+	if !empty.IsEmpty() {
+		t.Errorf("SubList(3, 3) not empty")
+	}
+}
+
+func TestList_Range(t *testing.T) {
+	list := NewList(1, 2, 3, 4, 5)
+
+	tests := []struct {
+		name string
+		got  *List[int]
+		want []int
+	}{
+		{name: "range", got: list.Range(1, 3), want: []int{2, 3}},
+		{name: "empty range", got: list.Range(2, 2), want: []int{}},
+		{name: "head", got: list.Head(2), want: []int{1, 2}},
+		{name: "head longer than the list", got: list.Head(10), want: []int{1, 2, 3, 4, 5}},
+		{name: "head of negative length", got: list.Head(-1), want: []int{}},
+		{name: "tail", got: list.Tail(2), want: []int{4, 5}},
+		{name: "tail longer than the list", got: list.Tail(10), want: []int{1, 2, 3, 4, 5}},
+		{name: "drop", got: list.Drop(2), want: []int{3, 4, 5}},
+		{name: "drop everything", got: list.Drop(10), want: []int{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.got.Slice(); !reflect.DeepEqual(append([]int{}, got...), tc.want) {
+				t.Errorf("Slice() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	// The copies do not share memory with the list.
+	head := list.Head(2)
+	head.Add(10)
+	*head.Get(0) = 100
+	if got := list.Slice(); !reflect.DeepEqual(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("list changed through a copy: %v", got)
+	}
+}
+
+func assertPanics(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s did not panic", name)
+		}
+	}()
+	f()
+}
@@ -0,0 +1,516 @@
+package l
+
+import "cmp"
+
+// TreeMap is an ordered map based on an AVL tree, a self-balancing binary search tree.
+// The keys are ordered by a comparator function, Get, Put and Delete run in O(log n), and the entries can be
+// iterated in ascending or descending key order. Split and Merge move entries between maps without copying them.
+type TreeMap[K any, V any] struct {
+	compare func(a, b K) int
+	root    *treeNode[K, V]
+}
+
+type treeNode[K any, V any] struct {
+	avlLinks[*treeNode[K, V]]
+	key   K
+	value V
+	size  int
+}
+
+// NewTreeMap creates an empty TreeMap that orders the keys by their natural order.
+func NewTreeMap[K cmp.Ordered, V any]() TreeMap[K, V] {
+	return NewTreeMapFunc[K, V](cmp.Compare[K])
+}
+
+// NewTreeMapFunc creates an empty TreeMap that orders the keys with the given comparator.
+// The comparator returns a negative number if a < b, zero if a == b and a positive number if a > b.
+//
+// Example usage:
+//
+//	m := NewTreeMapFunc[string, int](func(a, b string) int {
+//	    return cmp.Compare(len(a), len(b))
+//	})
+func NewTreeMapFunc[K any, V any](compare func(a, b K) int) TreeMap[K, V] {
+	return TreeMap[K, V]{compare: compare}
+}
+
+// Put stores the value under the given key, replacing the previous value if the key already exists.
+// It returns true if the key was not in the map before.
+func (m *TreeMap[K, V]) Put(key K, value V) bool {
+	var added bool
+	m.root, added = m.put(m.root, key, value)
+	return added
+}
+
+// Get returns a pointer to the value stored under the given key. If the key is not in the map, it returns nil.
+// Modifying the value through the pointer modifies the value in the map.
+func (m *TreeMap[K, V]) Get(key K) *V {
+	node := m.root
+	for node != nil {
+		c := m.compare(key, node.key)
+		switch {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			node = node.right
+		default:
+			return &node.value
+		}
+	}
+	return nil
+}
+
+// Contains returns true if the key is in the map.
+func (m *TreeMap[K, V]) Contains(key K) bool {
+	return m.Get(key) != nil
+}
+
+// Delete removes the key from the map. It returns true if the key was in the map.
+func (m *TreeMap[K, V]) Delete(key K) bool {
+	var deleted bool
+	m.root, deleted = m.delete(m.root, key)
+	return deleted
+}
+
+// Length returns the number of entries in the map.
+func (m *TreeMap[K, V]) Length() int {
+	return treeSize(m.root)
+}
+
+// IsEmpty returns true if the map has no entries, false otherwise.
+func (m *TreeMap[K, V]) IsEmpty() bool {
+	return m.root == nil
+}
+
+// Clear removes all entries from the map.
+func (m *TreeMap[K, V]) Clear() {
+	m.root = nil
+}
+
+// Min returns the entry with the smallest key. If the map is empty, it returns nil.
+func (m *TreeMap[K, V]) Min() *Entry[K, V] {
+	if m.root == nil {
+		return nil
+	}
+	node := m.root
+	for node.left != nil {
+		node = node.left
+	}
+	return node.entry()
+}
+
+// Max returns the entry with the largest key. If the map is empty, it returns nil.
+func (m *TreeMap[K, V]) Max() *Entry[K, V] {
+	if m.root == nil {
+		return nil
+	}
+	node := m.root
+	for node.right != nil {
+		node = node.right
+	}
+	return node.entry()
+}
+
+// Floor returns the entry with the largest key less than or equal to the given key.
+// If there is no such entry, it returns nil.
+func (m *TreeMap[K, V]) Floor(key K) *Entry[K, V] {
+	var found *treeNode[K, V]
+	node := m.root
+	for node != nil {
+		c := m.compare(key, node.key)
+		switch {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			found = node
+			node = node.right
+		default:
+			return node.entry()
+		}
+	}
+	return found.entry()
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to the given key.
+// If there is no such entry, it returns nil.
+func (m *TreeMap[K, V]) Ceiling(key K) *Entry[K, V] {
+	var found *treeNode[K, V]
+	node := m.root
+	for node != nil {
+		c := m.compare(key, node.key)
+		switch {
+		case c < 0:
+			found = node
+			node = node.left
+		case c > 0:
+			node = node.right
+		default:
+			return node.entry()
+		}
+	}
+	return found.entry()
+}
+
+// ForEach calls f for every entry of the map in ascending key order.
+func (m *TreeMap[K, V]) ForEach(f func(key K, value V)) {
+	m.Ascend(func(key K, value V) bool {
+		f(key, value)
+		return true
+	})
+}
+
+// Ascend calls f for every entry of the map in ascending key order. The iteration stops when f returns false.
+func (m *TreeMap[K, V]) Ascend(f func(key K, value V) bool) {
+	m.ascend(m.root, nil, nil, f)
+}
+
+// Descend calls f for every entry of the map in descending key order. The iteration stops when f returns false.
+func (m *TreeMap[K, V]) Descend(f func(key K, value V) bool) {
+	m.descend(m.root, nil, nil, f)
+}
+
+// Range calls f in ascending key order for every entry whose key is in the range [from, to).
+// The iteration stops when f returns false.
+func (m *TreeMap[K, V]) Range(from, to K, f func(key K, value V) bool) {
+	m.ascend(m.root, &from, &to, f)
+}
+
+// DescendRange calls f in descending key order for every entry whose key is in the range [from, to).
+// The iteration stops when f returns false.
+func (m *TreeMap[K, V]) DescendRange(from, to K, f func(key K, value V) bool) {
+	m.descend(m.root, &from, &to, f)
+}
+
+// Keys returns a List with all keys of the map in ascending order.
+func (m *TreeMap[K, V]) Keys() *List[K] {
+	keys := NewList[K]()
+	m.ForEach(func(key K, _ V) { keys.Add(key) })
+	return &keys
+}
+
+// Values returns a List with all values of the map in ascending order of their keys.
+func (m *TreeMap[K, V]) Values() *List[V] {
+	values := NewList[V]()
+	m.ForEach(func(_ K, value V) { values.Add(value) })
+	return &values
+}
+
+// All returns a function that calls yield with every entry of the map in ascending key order,
+// until yield returns false.
+func (m *TreeMap[K, V]) All() func(yield func(Entry[K, V]) bool) {
+	return func(yield func(Entry[K, V]) bool) {
+		m.Ascend(func(key K, value V) bool {
+			return yield(Entry[K, V]{Key: key, Value: value})
+		})
+	}
+}
+
+// Split moves all entries with a key greater than or equal to the given key into a new map and returns it.
+// The entries with smaller keys stay in m. Split runs in O(log n).
+func (m *TreeMap[K, V]) Split(key K) TreeMap[K, V] {
+	left, found, right := m.split(m.root, key)
+	if found != nil {
+		right = m.join(nil, found, right)
+	}
+	m.root = left
+	return TreeMap[K, V]{compare: m.compare, root: right}
+}
+
+// Merge moves all entries of other into m, leaving other empty. If a key exists in both maps, the value from
+// other is kept. Both maps must use the same ordering. Merge splits and joins whole subtrees, so it is faster
+// than putting the entries of other one by one.
+func (m *TreeMap[K, V]) Merge(other *TreeMap[K, V]) {
+	m.root = m.union(m.root, other.root)
+	other.root = nil
+}
+
+func (m *TreeMap[K, V]) put(node *treeNode[K, V], key K, value V) (*treeNode[K, V], bool) {
+	if node == nil {
+		return &treeNode[K, V]{avlLinks: avlLinks[*treeNode[K, V]]{height: 1}, key: key, value: value, size: 1}, true
+	}
+
+	var added bool
+	c := m.compare(key, node.key)
+	switch {
+	case c < 0:
+		node.left, added = m.put(node.left, key, value)
+	case c > 0:
+		node.right, added = m.put(node.right, key, value)
+	default:
+		node.value = value
+		return node, false
+	}
+	return avlBalance(node), added
+}
+
+func (m *TreeMap[K, V]) delete(node *treeNode[K, V], key K) (*treeNode[K, V], bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	var deleted bool
+	c := m.compare(key, node.key)
+	switch {
+	case c < 0:
+		node.left, deleted = m.delete(node.left, key)
+	case c > 0:
+		node.right, deleted = m.delete(node.right, key)
+	default:
+		if node.left == nil {
+			return node.right, true
+		}
+		if node.right == nil {
+			return node.left, true
+		}
+		var successor *treeNode[K, V]
+		node.right, successor = avlRemoveMin(node.right)
+		successor.left, successor.right = node.left, node.right
+		return avlBalance(successor), true
+	}
+	return avlBalance(node), deleted
+}
+
+// ascend visits the entries of the subtree in the range [from, to) in ascending order.
+// A nil bound means that the range is unbounded on that side. It returns false if the iteration was stopped.
+func (m *TreeMap[K, V]) ascend(node *treeNode[K, V], from, to *K, f func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	afterFrom := from == nil || m.compare(node.key, *from) >= 0
+	beforeTo := to == nil || m.compare(node.key, *to) < 0
+	if afterFrom && !m.ascend(node.left, from, to, f) {
+		return false
+	}
+	if afterFrom && beforeTo && !f(node.key, node.value) {
+		return false
+	}
+	if beforeTo {
+		return m.ascend(node.right, from, to, f)
+	}
+	return true
+}
+
+// descend visits the entries of the subtree in the range [from, to) in descending order.
+func (m *TreeMap[K, V]) descend(node *treeNode[K, V], from, to *K, f func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	afterFrom := from == nil || m.compare(node.key, *from) >= 0
+	beforeTo := to == nil || m.compare(node.key, *to) < 0
+	if beforeTo && !m.descend(node.right, from, to, f) {
+		return false
+	}
+	if afterFrom && beforeTo && !f(node.key, node.value) {
+		return false
+	}
+	if afterFrom {
+		return m.descend(node.left, from, to, f)
+	}
+	return true
+}
+
+// split divides the subtree into the nodes with smaller keys, the node with the given key and the nodes with
+// larger keys.
+func (m *TreeMap[K, V]) split(node *treeNode[K, V], key K) (*treeNode[K, V], *treeNode[K, V], *treeNode[K, V]) {
+	if node == nil {
+		return nil, nil, nil
+	}
+
+	c := m.compare(key, node.key)
+	switch {
+	case c < 0:
+		left, found, right := m.split(node.left, key)
+		return left, found, m.join(right, node, node.right)
+	case c > 0:
+		left, found, right := m.split(node.right, key)
+		return m.join(node.left, node, left), found, right
+	default:
+		left, right := node.left, node.right
+		node.left, node.right = nil, nil
+		avlUpdate(node)
+		return left, node, right
+	}
+}
+
+// join combines two subtrees and a middle node into a balanced tree.
+// All keys of left must be smaller and all keys of right must be larger than the key of middle.
+func (m *TreeMap[K, V]) join(left, middle, right *treeNode[K, V]) *treeNode[K, V] {
+	switch {
+	case avlHeight(left) > avlHeight(right)+1:
+		left.right = m.join(left.right, middle, right)
+		return avlBalance(left)
+	case avlHeight(right) > avlHeight(left)+1:
+		right.left = m.join(left, middle, right.left)
+		return avlBalance(right)
+	default:
+		middle.left, middle.right = left, right
+		avlUpdate(middle)
+		return middle
+	}
+}
+
+// union merges two subtrees. The nodes of b replace the nodes of a with equal keys.
+func (m *TreeMap[K, V]) union(a, b *treeNode[K, V]) *treeNode[K, V] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	left, _, right := m.split(a, b.key)
+	bLeft, bRight := b.left, b.right
+	return m.join(m.union(left, bLeft), b, m.union(right, bRight))
+}
+
+func (n *treeNode[K, V]) entry() *Entry[K, V] {
+	if n == nil {
+		return nil
+	}
+	return &Entry[K, V]{Key: n.key, Value: n.value}
+}
+
+func treeSize[K, V any](node *treeNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+func (n *treeNode[K, V]) links() *avlLinks[*treeNode[K, V]] {
+	return &n.avlLinks
+}
+
+func (n *treeNode[K, V]) update() {
+	n.size = 1 + treeSize(n.left) + treeSize(n.right)
+}
+
+// TreeSet is an ordered set based on a TreeMap.
+type TreeSet[K any] struct {
+	tree TreeMap[K, struct{}]
+}
+
+// NewTreeSet creates a TreeSet with the given items that orders them by their natural order.
+func NewTreeSet[K cmp.Ordered](items ...K) TreeSet[K] {
+	return NewTreeSetFunc(cmp.Compare[K], items...)
+}
+
+// NewTreeSetFunc creates a TreeSet with the given items that orders them with the given comparator.
+func NewTreeSetFunc[K any](compare func(a, b K) int, items ...K) TreeSet[K] {
+	set := TreeSet[K]{tree: NewTreeMapFunc[K, struct{}](compare)}
+	set.Add(items...)
+	return set
+}
+
+// Add adds items to the set. It returns the number of items that were not in the set before.
+func (s *TreeSet[K]) Add(item ...K) int {
+	added := 0
+	for _, i := range item {
+		if s.tree.Put(i, struct{}{}) {
+			added++
+		}
+	}
+	return added
+}
+
+// Remove removes the item from the set. It returns true if the item was in the set.
+func (s *TreeSet[K]) Remove(item K) bool {
+	return s.tree.Delete(item)
+}
+
+// Contains returns true if the item is in the set.
+func (s *TreeSet[K]) Contains(item K) bool {
+	return s.tree.Contains(item)
+}
+
+// Length returns the number of items in the set.
+func (s *TreeSet[K]) Length() int {
+	return s.tree.Length()
+}
+
+// IsEmpty returns true if the set has no items, false otherwise.
+func (s *TreeSet[K]) IsEmpty() bool {
+	return s.tree.IsEmpty()
+}
+
+// Clear removes all items from the set.
+func (s *TreeSet[K]) Clear() {
+	s.tree.Clear()
+}
+
+// Min returns the smallest item of the set. If the set is empty, it returns nil.
+func (s *TreeSet[K]) Min() *K {
+	return entryKey(s.tree.Min())
+}
+
+// Max returns the largest item of the set. If the set is empty, it returns nil.
+func (s *TreeSet[K]) Max() *K {
+	return entryKey(s.tree.Max())
+}
+
+// Floor returns the largest item less than or equal to the given item. If there is no such item, it returns nil.
+func (s *TreeSet[K]) Floor(item K) *K {
+	return entryKey(s.tree.Floor(item))
+}
+
+// Ceiling returns the smallest item greater than or equal to the given item. If there is no such item, it returns nil.
+func (s *TreeSet[K]) Ceiling(item K) *K {
+	return entryKey(s.tree.Ceiling(item))
+}
+
+// ForEach calls f for every item of the set in ascending order.
+func (s *TreeSet[K]) ForEach(f func(item K)) {
+	s.tree.ForEach(func(key K, _ struct{}) { f(key) })
+}
+
+// Ascend calls f for every item of the set in ascending order. The iteration stops when f returns false.
+func (s *TreeSet[K]) Ascend(f func(item K) bool) {
+	s.tree.Ascend(func(key K, _ struct{}) bool { return f(key) })
+}
+
+// Descend calls f for every item of the set in descending order. The iteration stops when f returns false.
+func (s *TreeSet[K]) Descend(f func(item K) bool) {
+	s.tree.Descend(func(key K, _ struct{}) bool { return f(key) })
+}
+
+// Range calls f in ascending order for every item in the range [from, to). The iteration stops when f returns false.
+func (s *TreeSet[K]) Range(from, to K, f func(item K) bool) {
+	s.tree.Range(from, to, func(key K, _ struct{}) bool { return f(key) })
+}
+
+// DescendRange calls f in descending order for every item in the range [from, to).
+// The iteration stops when f returns false.
+func (s *TreeSet[K]) DescendRange(from, to K, f func(item K) bool) {
+	s.tree.DescendRange(from, to, func(key K, _ struct{}) bool { return f(key) })
+}
+
+// Items returns a List with all items of the set in ascending order.
+func (s *TreeSet[K]) Items() *List[K] {
+	return s.tree.Keys()
+}
+
+// All returns a function that calls yield with every item of the set in ascending order, until yield returns false.
+func (s *TreeSet[K]) All() func(yield func(K) bool) {
+	return func(yield func(K) bool) {
+		s.Ascend(yield)
+	}
+}
+
+// Split moves all items greater than or equal to the given item into a new set and returns it.
+func (s *TreeSet[K]) Split(item K) TreeSet[K] {
+	return TreeSet[K]{tree: s.tree.Split(item)}
+}
+
+// Merge moves all items of other into s, leaving other empty.
+func (s *TreeSet[K]) Merge(other *TreeSet[K]) {
+	s.tree.Merge(&other.tree)
+}
+
+func entryKey[K, V any](entry *Entry[K, V]) *K {
+	if entry == nil {
+		return nil
+	}
+	return &entry.Key
+}
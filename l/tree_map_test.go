@@ -0,0 +1,267 @@
+package l
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// checkTree verifies the ordering, height and size of every node and returns the number of nodes.
+func checkTree[K, V any](t *testing.T, m *TreeMap[K, V]) {
+	t.Helper()
+
+	var check func(node *treeNode[K, V]) int
+	check = func(node *treeNode[K, V]) int {
+		if node == nil {
+			return 0
+		}
+		if node.left != nil && m.compare(node.left.key, node.key) >= 0 {
+			t.Fatalf("left child %v not smaller than %v", node.left.key, node.key)
+		}
+		if node.right != nil && m.compare(node.right.key, node.key) <= 0 {
+			t.Fatalf("right child %v not larger than %v", node.right.key, node.key)
+		}
+		lh, rh := check(node.left), check(node.right)
+		if lh-rh > 1 || rh-lh > 1 {
+			t.Fatalf("node %v is unbalanced: %d vs %d", node.key, lh, rh)
+		}
+		if node.height != 1+max(lh, rh) {
+			t.Fatalf("node %v has height %d, want %d", node.key, node.height, 1+max(lh, rh))
+		}
+		if node.size != 1+treeSize(node.left)+treeSize(node.right) {
+			t.Fatalf("node %v has wrong size %d", node.key, node.size)
+		}
+		return node.height
+	}
+	check(m.root)
+}
+
+func TestTreeMap_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	m := NewTreeMap[int, int]()
+	expected := map[int]int{}
+
+	for i := 0; i < 5000; i++ {
+		key := rnd.Intn(500)
+		_, exists := expected[key]
+		if rnd.Intn(3) == 0 {
+			if got := m.Delete(key); got != exists {
+				t.Fatalf("Delete(%d) = %v, want %v", key, got, exists)
+			}
+			delete(expected, key)
+		} else {
+			if got := m.Put(key, i); got == exists {
+				t.Fatalf("Put(%d) = %v, want %v", key, got, !exists)
+			}
+			expected[key] = i
+		}
+	}
+	checkTree(t, &m)
+
+	keys := make([]int, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+		if got := m.Get(key); got == nil || *got != expected[key] {
+			t.Fatalf("Get(%d) = %v, want %d", key, got, expected[key])
+		}
+	}
+	sort.Ints(keys)
+	if got := m.Keys().Slice(); !reflect.DeepEqual(got, keys) {
+		t.Errorf("Keys() = %v, want %v", got, keys)
+	}
+	if m.Length() != len(keys) {
+		t.Errorf("Length() = %d, want %d", m.Length(), len(keys))
+	}
+}
+
+func TestTreeMap_Navigation(t *testing.T) {
+	m := NewTreeMap[int, string]()
+	for _, key := range []int{10, 20, 30, 40} {
+		m.Put(key, "")
+	}
+
+	tests := []struct {
+		name    string
+		key     int
+		floor   *int
+		ceiling *int
+	}{
+		{name: "below minimum", key: 5, floor: nil, ceiling: intPtr(10)},
+		{name: "exact key", key: 20, floor: intPtr(20), ceiling: intPtr(20)},
+		{name: "between keys", key: 25, floor: intPtr(20), ceiling: intPtr(30)},
+		{name: "above maximum", key: 45, floor: intPtr(40), ceiling: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := entryKey(m.Floor(tc.key)); !reflect.DeepEqual(got, tc.floor) {
+				t.Errorf("Floor(%d) = %v, want %v", tc.key, got, tc.floor)
+			}
+			if got := entryKey(m.Ceiling(tc.key)); !reflect.DeepEqual(got, tc.ceiling) {
+				t.Errorf("Ceiling(%d) = %v, want %v", tc.key, got, tc.ceiling)
+			}
+		})
+	}
+
+	if got := m.Min(); got == nil || got.Key != 10 {
+		t.Errorf("Min() = %v, want 10", got)
+	}
+	if got := m.Max(); got == nil || got.Key != 40 {
+		t.Errorf("Max() = %v, want 40", got)
+	}
+
+	empty := NewTreeMap[int, string]()
+	if empty.Min() != nil || empty.Max() != nil || empty.Floor(1) != nil {
+		t.Errorf("navigation on empty map returned an entry")
+	}
+}
+
+func TestTreeMap_Iteration(t *testing.T) {
+	m := NewTreeMap[int, int]()
+	for i := 1; i <= 10; i++ {
+		m.Put(i, i*i)
+	}
+
+	tests := []struct {
+		name string
+		iter func(f func(int, int) bool)
+		stop int
+		want []int
+	}{
+		{name: "ascend", iter: m.Ascend, want: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{name: "descend", iter: m.Descend, want: []int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}},
+		{name: "ascend stopped", iter: m.Ascend, stop: 3, want: []int{1, 2, 3}},
+		{
+			name: "range",
+			iter: func(f func(int, int) bool) { m.Range(3, 7, f) },
+			want: []int{3, 4, 5, 6},
+		},
+		{
+			name: "descend range",
+			iter: func(f func(int, int) bool) { m.DescendRange(3, 7, f) },
+			want: []int{6, 5, 4, 3},
+		},
+		{
+			name: "empty range",
+			iter: func(f func(int, int) bool) { m.Range(7, 3, f) },
+			want: []int{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []int{}
+			tc.iter(func(key, value int) bool {
+				if value != key*key {
+					t.Errorf("value of %d = %d, want %d", key, value, key*key)
+				}
+				got = append(got, key)
+				return tc.stop == 0 || len(got) < tc.stop
+			})
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("iteration = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTreeMap_SplitMerge(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  []int
+		split int
+		left  []int
+		right []int
+	}{
+		{name: "empty map", keys: []int{}, split: 5, left: []int{}, right: []int{}},
+		{name: "existing key", keys: []int{1, 2, 3, 4, 5, 6}, split: 4, left: []int{1, 2, 3}, right: []int{4, 5, 6}},
+		{name: "missing key", keys: []int{1, 3, 5, 7}, split: 4, left: []int{1, 3}, right: []int{5, 7}},
+		{name: "below all keys", keys: []int{1, 2}, split: 0, left: []int{}, right: []int{1, 2}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewTreeMap[int, int]()
+			for _, key := range tc.keys {
+				m.Put(key, key)
+			}
+
+			right := m.Split(tc.split)
+			checkTree(t, &m)
+			checkTree(t, &right)
+			if got := m.Keys().Slice(); !reflect.DeepEqual(got, tc.left) {
+				t.Errorf("left keys = %v, want %v", got, tc.left)
+			}
+			if got := right.Keys().Slice(); !reflect.DeepEqual(got, tc.right) {
+				t.Errorf("right keys = %v, want %v", got, tc.right)
+			}
+
+			m.Merge(&right)
+			checkTree(t, &m)
+			if got := m.Keys().Slice(); !reflect.DeepEqual(got, tc.keys) {
+				t.Errorf("keys after Merge = %v, want %v", got, tc.keys)
+			}
+			if !right.IsEmpty() {
+				t.Errorf("merged map is not empty")
+			}
+		})
+	}
+}
+
+func TestTreeMap_MergeOverlapping(t *testing.T) {
+	a := NewTreeMap[int, string]()
+	b := NewTreeMap[int, string]()
+	for i := 0; i < 100; i += 2 {
+		a.Put(i, "a")
+	}
+	for i := 0; i < 100; i += 3 {
+		b.Put(i, "b")
+	}
+
+	a.Merge(&b)
+	checkTree(t, &a)
+	for i := 0; i < 100; i++ {
+		got := a.Get(i)
+		switch {
+		case i%3 == 0:
+			if got == nil || *got != "b" {
+				t.Errorf("Get(%d) = %v, want b", i, got)
+			}
+		case i%2 == 0:
+			if got == nil || *got != "a" {
+				t.Errorf("Get(%d) = %v, want a", i, got)
+			}
+		default:
+			if got != nil {
+				t.Errorf("Get(%d) = %v, want nil", i, *got)
+			}
+		}
+	}
+}
+
+func TestTreeSet(t *testing.T) {
+	s := NewTreeSetFunc(func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) }, "b", "A", "c")
+	if s.Add("a", "d") != 1 {
+		t.Errorf("Add() did not treat a and A as equal")
+	}
+	if got := s.Items().Slice(); !reflect.DeepEqual(got, []string{"A", "b", "c", "d"}) {
+		t.Errorf("Items() = %v", got)
+	}
+	if got := s.Ceiling("B"); got == nil || *got != "b" {
+		t.Errorf("Ceiling(B) = %v, want b", got)
+	}
+	if !s.Remove("C") || s.Contains("c") {
+		t.Errorf("Remove(C) did not remove c")
+	}
+
+	right := s.Split("b")
+	if got := right.Items().Slice(); !reflect.DeepEqual(got, []string{"b", "d"}) {
+		t.Errorf("Split() = %v, want [b d]", got)
+	}
+	s.Merge(&right)
+	if s.Length() != 3 || *s.Min() != "A" || *s.Max() != "d" {
+		t.Errorf("Merge() = %v", s.Items().Slice())
+	}
+}
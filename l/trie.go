@@ -0,0 +1,383 @@
+package l
+
+import (
+	"cmp"
+	"sort"
+)
+
+// RadixTree is a compressed prefix tree that maps keys of type []K to values of type V.
+// Every edge of the tree is labelled with a sequence of key elements, so chains of nodes with a single child are
+// collapsed into one node. Lookups run in O(len(key)) regardless of the number of keys, and the keys are iterated
+// in lexicographic order. The zero value is an empty tree ready to use.
+type RadixTree[K cmp.Ordered, V any] struct {
+	root *radixNode[K, V]
+	size int
+}
+
+type radixNode[K cmp.Ordered, V any] struct {
+	prefix   []K
+	value    V
+	hasValue bool
+	children []*radixNode[K, V]
+}
+
+// NewRadixTree creates an empty RadixTree.
+func NewRadixTree[K cmp.Ordered, V any]() RadixTree[K, V] {
+	return RadixTree[K, V]{}
+}
+
+// Insert stores the value under the given key, replacing the previous value if the key already exists.
+// It returns true if the key was not in the tree before.
+func (t *RadixTree[K, V]) Insert(key []K, value V) bool {
+	if t.root == nil {
+		t.root = &radixNode[K, V]{}
+	}
+
+	node := t.root
+	for {
+		if len(key) == 0 {
+			added := !node.hasValue
+			node.value = value
+			node.hasValue = true
+			if added {
+				t.size++
+			}
+			return added
+		}
+
+		index, child := node.child(key[0])
+		if child == nil {
+			leaf := &radixNode[K, V]{prefix: append([]K(nil), key...), value: value, hasValue: true}
+			node.children = append(node.children, nil)
+			copy(node.children[index+1:], node.children[index:])
+			node.children[index] = leaf
+			t.size++
+			return true
+		}
+
+		common := commonPrefixLength(child.prefix, key)
+		if common < len(child.prefix) {
+			// The key diverges in the middle of the edge, so the edge is split at the divergence point.
+			middle := &radixNode[K, V]{prefix: append([]K(nil), key[:common]...)}
+			child.prefix = child.prefix[common:]
+			middle.children = []*radixNode[K, V]{child}
+			node.children[index] = middle
+			child = middle
+		}
+		node = child
+		key = key[common:]
+	}
+}
+
+// Get returns a pointer to the value stored under the given key. If the key is not in the tree, it returns nil.
+// Modifying the value through the pointer modifies the value in the tree.
+func (t *RadixTree[K, V]) Get(key []K) *V {
+	node := t.find(key)
+	if node == nil || !node.hasValue {
+		return nil
+	}
+	return &node.value
+}
+
+// Contains returns true if the key is in the tree.
+func (t *RadixTree[K, V]) Contains(key []K) bool {
+	return t.Get(key) != nil
+}
+
+// Delete removes the key from the tree. It returns true if the key was in the tree.
+func (t *RadixTree[K, V]) Delete(key []K) bool {
+	if t.root == nil {
+		return false
+	}
+
+	var parent *radixNode[K, V]
+	node := t.root
+	for len(key) > 0 {
+		_, child := node.child(key[0])
+		if child == nil || !hasPrefix(key, child.prefix) {
+			return false
+		}
+		parent = node
+		node = child
+		key = key[len(child.prefix):]
+	}
+	if !node.hasValue {
+		return false
+	}
+
+	var zero V
+	node.value = zero
+	node.hasValue = false
+	t.size--
+
+	if parent == nil {
+		return true
+	}
+	if len(node.children) == 0 {
+		index, _ := parent.child(node.prefix[0])
+		parent.children = append(parent.children[:index], parent.children[index+1:]...)
+		if parent != t.root && !parent.hasValue && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	} else if len(node.children) == 1 {
+		node.mergeChild()
+	}
+	return true
+}
+
+// LongestPrefix returns the longest key in the tree that is a prefix of the given key, together with a pointer
+// to its value. If no key in the tree is a prefix of the given key, it returns nil and a nil pointer.
+//
+// Example usage:
+//
+//	t := NewTrie[string]()
+//	t.Insert("/api", "api")
+//	t.Insert("/api/users", "users")
+//	key, value := t.LongestPrefix("/api/users/42") // key is "/api/users", *value is "users"
+func (t *RadixTree[K, V]) LongestPrefix(key []K) ([]K, *V) {
+	if t.root == nil {
+		return nil, nil
+	}
+
+	var match *radixNode[K, V]
+	matchLength := 0
+	depth := 0
+	node := t.root
+	for {
+		if node.hasValue {
+			match = node
+			matchLength = depth
+		}
+		if depth == len(key) {
+			break
+		}
+		_, child := node.child(key[depth])
+		if child == nil || !hasPrefix(key[depth:], child.prefix) {
+			break
+		}
+		node = child
+		depth += len(child.prefix)
+	}
+
+	if match == nil {
+		return nil, nil
+	}
+	return append([]K(nil), key[:matchLength]...), &match.value
+}
+
+// WalkPrefix calls f for every key that starts with the given prefix, in lexicographic order.
+// The walk stops when f returns false. The key passed to f is a fresh copy that f may keep.
+func (t *RadixTree[K, V]) WalkPrefix(prefix []K, f func(key []K, value V) bool) {
+	if t.root == nil {
+		return
+	}
+
+	node := t.root
+	path := []K{}
+	for len(prefix) > 0 {
+		_, child := node.child(prefix[0])
+		if child == nil {
+			return
+		}
+		if !hasPrefix(prefix, child.prefix) {
+			// The prefix may end in the middle of the edge.
+			if !hasPrefix(child.prefix, prefix) {
+				return
+			}
+			prefix = prefix[:0]
+		} else {
+			prefix = prefix[len(child.prefix):]
+		}
+		path = append(path, child.prefix...)
+		node = child
+	}
+	node.walk(path, f)
+}
+
+// ForEach calls f for every key and value in the tree, in lexicographic order of the keys.
+func (t *RadixTree[K, V]) ForEach(f func(key []K, value V)) {
+	t.WalkPrefix(nil, func(key []K, value V) bool {
+		f(key, value)
+		return true
+	})
+}
+
+// All returns a function that calls yield with every entry of the tree in lexicographic order of the keys,
+// until yield returns false.
+func (t *RadixTree[K, V]) All() func(yield func(Entry[[]K, V]) bool) {
+	return func(yield func(Entry[[]K, V]) bool) {
+		t.WalkPrefix(nil, func(key []K, value V) bool {
+			return yield(Entry[[]K, V]{Key: key, Value: value})
+		})
+	}
+}
+
+// Keys returns a List with all keys of the tree in lexicographic order.
+func (t *RadixTree[K, V]) Keys() *List[[]K] {
+	keys := NewList[[]K]()
+	t.ForEach(func(key []K, _ V) { keys.Add(key) })
+	return &keys
+}
+
+// Length returns the number of keys in the tree.
+func (t *RadixTree[K, V]) Length() int {
+	return t.size
+}
+
+// IsEmpty returns true if the tree has no keys, false otherwise.
+func (t *RadixTree[K, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear removes all keys from the tree.
+func (t *RadixTree[K, V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+func (t *RadixTree[K, V]) find(key []K) *radixNode[K, V] {
+	if t.root == nil {
+		return nil
+	}
+
+	node := t.root
+	for len(key) > 0 {
+		_, child := node.child(key[0])
+		if child == nil || !hasPrefix(key, child.prefix) {
+			return nil
+		}
+		node = child
+		key = key[len(child.prefix):]
+	}
+	return node
+}
+
+// child returns the child whose edge starts with the given element together with its index.
+// If there is no such child, it returns the index at which it would be inserted and nil.
+func (n *radixNode[K, V]) child(first K) (int, *radixNode[K, V]) {
+	index := sort.Search(len(n.children), func(i int) bool { return n.children[i].prefix[0] >= first })
+	if index < len(n.children) && n.children[index].prefix[0] == first {
+		return index, n.children[index]
+	}
+	return index, nil
+}
+
+// mergeChild collapses the only child of the node into the node.
+func (n *radixNode[K, V]) mergeChild() {
+	child := n.children[0]
+	n.prefix = append(append([]K(nil), n.prefix...), child.prefix...)
+	n.value = child.value
+	n.hasValue = child.hasValue
+	n.children = child.children
+}
+
+// walk visits the values of the subtree in order. It returns false if the walk was stopped.
+func (n *radixNode[K, V]) walk(path []K, f func(key []K, value V) bool) bool {
+	if n.hasValue && !f(append([]K(nil), path...), n.value) {
+		return false
+	}
+	for _, child := range n.children {
+		if !child.walk(append(path, child.prefix...), f) {
+			return false
+		}
+	}
+	return true
+}
+
+func commonPrefixLength[K comparable](a, b []K) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+func hasPrefix[K comparable](s, prefix []K) bool {
+	return len(s) >= len(prefix) && commonPrefixLength(s, prefix) == len(prefix)
+}
+
+// Trie is a RadixTree with string keys, for example for route matching or autocompletion.
+// The zero value is an empty trie ready to use.
+type Trie[V any] struct {
+	tree RadixTree[byte, V]
+}
+
+// NewTrie creates an empty Trie.
+func NewTrie[V any]() Trie[V] {
+	return Trie[V]{}
+}
+
+// Insert stores the value under the given key, replacing the previous value if the key already exists.
+// It returns true if the key was not in the trie before.
+func (t *Trie[V]) Insert(key string, value V) bool {
+	return t.tree.Insert([]byte(key), value)
+}
+
+// Get returns a pointer to the value stored under the given key. If the key is not in the trie, it returns nil.
+func (t *Trie[V]) Get(key string) *V {
+	return t.tree.Get([]byte(key))
+}
+
+// Contains returns true if the key is in the trie.
+func (t *Trie[V]) Contains(key string) bool {
+	return t.tree.Contains([]byte(key))
+}
+
+// Delete removes the key from the trie. It returns true if the key was in the trie.
+func (t *Trie[V]) Delete(key string) bool {
+	return t.tree.Delete([]byte(key))
+}
+
+// LongestPrefix returns the longest key in the trie that is a prefix of the given key, together with a pointer to
+// its value. If no key in the trie is a prefix of the given key, it returns an empty string and a nil pointer.
+func (t *Trie[V]) LongestPrefix(key string) (string, *V) {
+	prefix, value := t.tree.LongestPrefix([]byte(key))
+	return string(prefix), value
+}
+
+// WalkPrefix calls f for every key that starts with the given prefix, in lexicographic order.
+// The walk stops when f returns false.
+func (t *Trie[V]) WalkPrefix(prefix string, f func(key string, value V) bool) {
+	t.tree.WalkPrefix([]byte(prefix), func(key []byte, value V) bool {
+		return f(string(key), value)
+	})
+}
+
+// ForEach calls f for every key and value in the trie, in lexicographic order of the keys.
+func (t *Trie[V]) ForEach(f func(key string, value V)) {
+	t.tree.ForEach(func(key []byte, value V) {
+		f(string(key), value)
+	})
+}
+
+// All returns a function that calls yield with every entry of the trie in lexicographic order of the keys,
+// until yield returns false.
+func (t *Trie[V]) All() func(yield func(Entry[string, V]) bool) {
+	return func(yield func(Entry[string, V]) bool) {
+		t.WalkPrefix("", func(key string, value V) bool {
+			return yield(Entry[string, V]{Key: key, Value: value})
+		})
+	}
+}
+
+// Keys returns a List with all keys of the trie in lexicographic order.
+func (t *Trie[V]) Keys() *List[string] {
+	keys := NewList[string]()
+	t.ForEach(func(key string, _ V) { keys.Add(key) })
+	return &keys
+}
+
+// Length returns the number of keys in the trie.
+func (t *Trie[V]) Length() int {
+	return t.tree.Length()
+}
+
+// IsEmpty returns true if the trie has no keys, false otherwise.
+func (t *Trie[V]) IsEmpty() bool {
+	return t.tree.IsEmpty()
+}
+
+// Clear removes all keys from the trie.
+func (t *Trie[V]) Clear() {
+	t.tree.Clear()
+}
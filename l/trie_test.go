@@ -0,0 +1,252 @@
+package l
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestTrie_InsertGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    []string
+		lookups map[string]*int
+	}{
+		{
+			name:    "empty trie",
+			keys:    []string{},
+			lookups: map[string]*int{"": nil, "a": nil},
+		},
+		{
+			name:    "shared prefixes",
+			keys:    []string{"romane", "romanus", "romulus", "rubens", "ruber"},
+			lookups: map[string]*int{"romane": intPtr(0), "romulus": intPtr(2), "ruber": intPtr(4), "rom": nil, "rubicon": nil},
+		},
+		{
+			name:    "key is prefix of another key",
+			keys:    []string{"test", "team", "te", ""},
+			lookups: map[string]*int{"te": intPtr(2), "": intPtr(3), "t": nil, "tea": nil},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			trie := NewTrie[int]()
+			for i, key := range tc.keys {
+				if !trie.Insert(key, i) {
+					t.Errorf("Insert(%q) = false, want true", key)
+				}
+			}
+			if trie.Length() != len(tc.keys) {
+				t.Errorf("Length() = %d, want %d", trie.Length(), len(tc.keys))
+			}
+			for key, want := range tc.lookups {
+				got := trie.Get(key)
+				if (got == nil) != (want == nil) || (got != nil && *got != *want) {
+					t.Errorf("Get(%q) = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestTrie_Replace(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.Insert("key", "old")
+	if trie.Insert("key", "new") {
+		t.Errorf("Insert() of existing key = true, want false")
+	}
+	if got := trie.Get("key"); got == nil || *got != "new" {
+		t.Errorf("Get() = %v, want new", got)
+	}
+	*trie.Get("key") = "modified"
+	if got := trie.Get("key"); *got != "modified" {
+		t.Errorf("Get() after modification through pointer = %v, want modified", *got)
+	}
+}
+
+func TestTrie_Delete(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   []string
+		delete []string
+		want   []string
+	}{
+		{
+			name:   "delete missing key",
+			keys:   []string{"abc"},
+			delete: []string{"ab", "abcd", "x"},
+			want:   []string{"abc"},
+		},
+		{
+			name:   "delete leaf merges parent",
+			keys:   []string{"team", "test"},
+			delete: []string{"team"},
+			want:   []string{"test"},
+		},
+		{
+			name:   "delete inner key",
+			keys:   []string{"te", "team", "test"},
+			delete: []string{"te"},
+			want:   []string{"team", "test"},
+		},
+		{
+			name:   "delete empty key",
+			keys:   []string{"", "a"},
+			delete: []string{""},
+			want:   []string{"a"},
+		},
+		{
+			name:   "delete everything",
+			keys:   []string{"a", "ab", "abc"},
+			delete: []string{"ab", "abc", "a"},
+			want:   []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			trie := NewTrie[bool]()
+			for _, key := range tc.keys {
+				trie.Insert(key, true)
+			}
+			for _, key := range tc.delete {
+				trie.Delete(key)
+			}
+			if got := trie.Keys().Slice(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Keys() = %v, want %v", got, tc.want)
+			}
+			if trie.Length() != len(tc.want) {
+				t.Errorf("Length() = %d, want %d", trie.Length(), len(tc.want))
+			}
+		})
+	}
+}
+
+func TestTrie_LongestPrefix(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.Insert("/", "root")
+	trie.Insert("/api", "api")
+	trie.Insert("/api/users", "users")
+	trie.Insert("/static", "static")
+
+	tests := []struct {
+		key       string
+		wantKey   string
+		wantValue string
+	}{
+		{key: "/api/users/42", wantKey: "/api/users", wantValue: "users"},
+		{key: "/api/user", wantKey: "/api", wantValue: "api"},
+		{key: "/api", wantKey: "/api", wantValue: "api"},
+		{key: "/index.html", wantKey: "/", wantValue: "root"},
+		{key: "", wantKey: "", wantValue: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.key, func(t *testing.T) {
+			key, value := trie.LongestPrefix(tc.key)
+			if key != tc.wantKey {
+				t.Errorf("LongestPrefix(%q) key = %q, want %q", tc.key, key, tc.wantKey)
+			}
+			if (value == nil) != (tc.wantValue == "") || (value != nil && *value != tc.wantValue) {
+				t.Errorf("LongestPrefix(%q) value = %v, want %q", tc.key, value, tc.wantValue)
+			}
+		})
+	}
+}
+
+func TestTrie_WalkPrefix(t *testing.T) {
+	trie := NewTrie[int]()
+	for i, key := range []string{"car", "cart", "carbon", "cat", "dog", "ca"} {
+		trie.Insert(key, i)
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		limit  int
+		want   []string
+	}{
+		{name: "all keys", prefix: "", limit: -1, want: []string{"ca", "car", "carbon", "cart", "cat", "dog"}},
+		{name: "prefix at node", prefix: "car", limit: -1, want: []string{"car", "carbon", "cart"}},
+		{name: "prefix inside edge", prefix: "carb", limit: -1, want: []string{"carbon"}},
+		{name: "no match", prefix: "cab", limit: -1, want: []string{}},
+		{name: "stopped early", prefix: "c", limit: 2, want: []string{"ca", "car"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []string{}
+			trie.WalkPrefix(tc.prefix, func(key string, _ int) bool {
+				got = append(got, key)
+				return tc.limit < 0 || len(got) < tc.limit
+			})
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("WalkPrefix(%q) = %v, want %v", tc.prefix, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRadixTree_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	tree := NewRadixTree[int, int]()
+	expected := map[string]int{}
+
+	key := func() []int {
+		k := make([]int, rnd.Intn(6))
+		for i := range k {
+			k[i] = rnd.Intn(3)
+		}
+		return k
+	}
+	name := func(k []int) string {
+		b := make([]byte, len(k))
+		for i, v := range k {
+			b[i] = byte('0' + v)
+		}
+		return string(b)
+	}
+
+	for i := 0; i < 5000; i++ {
+		k := key()
+		if rnd.Intn(3) == 0 {
+			_, exists := expected[name(k)]
+			if got := tree.Delete(k); got != exists {
+				t.Fatalf("Delete(%v) = %v, want %v", k, got, exists)
+			}
+			delete(expected, name(k))
+		} else {
+			_, exists := expected[name(k)]
+			if got := tree.Insert(k, i); got == exists {
+				t.Fatalf("Insert(%v) = %v, want %v", k, got, !exists)
+			}
+			expected[name(k)] = i
+		}
+	}
+
+	want := make([]string, 0, len(expected))
+	for k := range expected {
+		want = append(want, k)
+	}
+	sort.Strings(want)
+
+	got := []string{}
+	tree.ForEach(func(k []int, v int) {
+		if expected[name(k)] != v {
+			t.Errorf("value of %v = %d, want %d", k, v, expected[name(k)])
+		}
+		got = append(got, name(k))
+	})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ForEach() keys = %v, want %v", got, want)
+	}
+	if tree.Length() != len(want) {
+		t.Errorf("Length() = %d, want %d", tree.Length(), len(want))
+	}
+}